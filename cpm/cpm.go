@@ -0,0 +1,128 @@
+package cpm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+// Code represents EMV Consumer-Presented Mode payload structure.
+type Code struct {
+	PayloadFormatIndicator string                 `emv:"85"` // The first data object
+	ApplicationTemplates   []ApplicationTemplate  `emv:"61"`
+	CommonDataTemplate     NullCommonDataTemplate `emv:"62"`
+}
+
+const (
+	tagName = "emv"
+
+	payloadFormatIndicatorTag = "85"
+
+	// PayloadFormatIndicator represents the value of Payload Format Indicator of version 01.
+	PayloadFormatIndicator = "CPV01"
+
+	adfNameMinLength = 5
+	adfNameMaxLength = 16
+)
+
+// ValidatorFunc is an adapter for functions as validator.
+type ValidatorFunc func(*Code) error
+
+// Decode decodes base64 encoded payload and validates as EMV CPM.
+func Decode(payload []byte, vfs ...ValidatorFunc) (*Code, error) {
+	b := make([]byte, base64.StdEncoding.DecodedLen(len(payload)))
+	n, err := base64.StdEncoding.Decode(b, payload)
+	if err != nil {
+		return nil, NewInvalidFormat(fmt.Sprintf("cpm: failed to decode base64: %s", err))
+	}
+	return DecodeBytes(b[:n], vfs...)
+}
+
+// DecodeBytes decodes BER-TLV encoded payload and validates as EMV CPM.
+func DecodeBytes(payload []byte, vfs ...ValidatorFunc) (*Code, error) {
+	if t, _, err := tlv.ReadBERTLV(payload); err != nil || t.Tag != payloadFormatIndicatorTag {
+		return nil, NewInvalidFormat(fmt.Sprintf("cpm: first data object should be %s", payloadFormatIndicatorTag))
+	}
+
+	var v struct {
+		Code
+		Others []tlv.BERTLV `emv:"*"`
+	}
+	if err := tlv.NewBERDecoder(payload, tagName).Decode(&v); err != nil {
+		return nil, NewInvalidFormat(fmt.Sprintf("cpm: %s", err))
+	}
+	if len(v.Others) != 0 {
+		return nil, NewInvalidFormat(fmt.Sprintf("cpm: unexpected tag %s", v.Others[0].Tag))
+	}
+	c := v.Code
+
+	vfs = append(
+		vfs,
+		validatePayloadFormatIndicator,
+		validateApplicationTemplates,
+	)
+	for _, f := range vfs {
+		if err := f(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	return &c, nil
+}
+
+// Encode encodes to base64 encoded EMV CPM payload.
+func Encode(c *Code, vfs ...ValidatorFunc) ([]byte, error) {
+	b, err := EncodeBytes(c, vfs...)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, base64.StdEncoding.EncodedLen(len(b)))
+	base64.StdEncoding.Encode(buf, b)
+	return buf, nil
+}
+
+// EncodeBytes encodes to BER-TLV encoded EMV CPM payload.
+func EncodeBytes(c *Code, vfs ...ValidatorFunc) ([]byte, error) {
+	if c == nil {
+		return nil, errors.New("cpm: nil is not allowed")
+	}
+
+	vfs = append(
+		vfs,
+		validatePayloadFormatIndicator,
+		validateApplicationTemplates,
+	)
+	for _, f := range vfs {
+		if err := f(c); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tlv.NewBEREncoder(&buf, tagName).Encode(c); err != nil {
+		return nil, fmt.Errorf("cpm: failed to encode: %s", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func validatePayloadFormatIndicator(c *Code) error {
+	if c.PayloadFormatIndicator != PayloadFormatIndicator {
+		return NewInvalidFormat(fmt.Sprintf("cpm: PayloadFormatIndicator should be %s", PayloadFormatIndicator))
+	}
+	return nil
+}
+
+func validateApplicationTemplates(c *Code) error {
+	if len(c.ApplicationTemplates) == 0 {
+		return NewInvalidFormat("cpm: at least one ApplicationTemplate should be represented")
+	}
+	for _, a := range c.ApplicationTemplates {
+		if l := len(a.ADFName); l < adfNameMinLength || adfNameMaxLength < l {
+			return NewInvalidFormat(fmt.Sprintf("cpm: length of ApplicationTemplate.ADFName should be between %d and %d", adfNameMinLength, adfNameMaxLength))
+		}
+	}
+	return nil
+}
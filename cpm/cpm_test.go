@@ -0,0 +1,192 @@
+package cpm_test
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mercari.io/go-emv-code/cpm"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+var cpmSamplePayload = []byte("hQVDUFYwMWETTwegAAAAVVVVUAhQcm9kdWN0MWETTwegAAAAZmZmUAhQcm9kdWN0MmJKWggSNFZ4kBI0WF8gDkNBUkRIT0xERVIvRU1WXy0IcnVlc2RlZW5kIp8QBwYBCgMAAACfJgjmBtNfGma4op8nAYCfNgIABYICHAA=")
+
+var cpmSampleCode = &cpm.Code{
+	PayloadFormatIndicator: "CPV01",
+	ApplicationTemplates: []cpm.ApplicationTemplate{
+		{
+			DataObjects: cpm.DataObjects{
+				ADFName:          []byte{0xA0, 0x00, 0x00, 0x00, 0x55, 0x55, 0x55},
+				ApplicationLabel: "Product1",
+			},
+		},
+		{
+			DataObjects: cpm.DataObjects{
+				ADFName:          []byte{0xA0, 0x00, 0x00, 0x00, 0x66, 0x66, 0x66},
+				ApplicationLabel: "Product2",
+			},
+		},
+	},
+	CommonDataTemplate: cpm.NullCommonDataTemplate{
+		DataObjects: cpm.DataObjects{
+			ApplicationPAN:     []byte{0x12, 0x34, 0x56, 0x78, 0x90, 0x12, 0x34, 0x58},
+			CardholderName:     "CARDHOLDER/EMV",
+			LanguagePreference: "ruesdeen",
+		},
+		CommonDataTransparentTemplate: []tlv.BERTLV{
+			{Tag: "9F10", Value: []byte{0x06, 0x01, 0x0A, 0x03, 0x00, 0x00, 0x00}},
+			{Tag: "9F26", Value: []byte{0xE6, 0x06, 0xD3, 0x5F, 0x1A, 0x66, 0xB8, 0xA2}},
+			{Tag: "9F27", Value: []byte{0x80}},
+			{Tag: "9F36", Value: []byte{0x00, 0x05}},
+			{Tag: "82", Value: []byte{0x1C, 0x00}},
+		},
+		Valid: true,
+	},
+}
+
+type invalidFormat interface {
+	InvalidFormat() bool
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    []byte
+		want    *cpm.Code
+		wantErr bool
+	}{
+		{
+			name: "pass",
+			give: cpmSamplePayload,
+			want: cpmSampleCode,
+		},
+		{
+			name:    "err: not base64",
+			give:    []byte("hQVDUFYwM!"),
+			wantErr: true,
+		},
+		{
+			name:    "err: missing PayloadFormatIndicator",
+			give:    []byte("YRNPB6AAAABVVVVQCFByb2R1Y3Qx"),
+			wantErr: true,
+		},
+		{
+			name:    "err: missing ApplicationTemplate",
+			give:    []byte("hQVDUFYwMQ=="),
+			wantErr: true,
+		},
+		{
+			name:    "err: duplicated CommonDataTemplate",
+			give:    []byte("hQVDUFYwMWEJTwegAAAAVVVVYgNaARJiA1oBEg=="),
+			wantErr: true,
+		},
+		{
+			name:    "err: unexpected tag",
+			give:    []byte("hQVDUFYwMWEJTwegAAAAVVVVnwIB/w=="),
+			wantErr: true,
+		},
+		{
+			name:    "err: truncated value",
+			give:    []byte("hQVDUFYwMWETTwegAAAAVVVVUAhQcm9kdWN0"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cpm.Decode(tt.give)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				if e, ok := err.(invalidFormat); !ok || !e.InvalidFormat() {
+					t.Errorf("Decode() unexpected error passed error = %v", err)
+				}
+			}
+
+			if tt.want != nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Decode() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    *cpm.Code
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name: "pass",
+			give: cpmSampleCode,
+			want: cpmSamplePayload,
+		},
+		{
+			name: "err: invalid PayloadFormatIndicator",
+			give: &cpm.Code{
+				PayloadFormatIndicator: "CPV02",
+				ApplicationTemplates:   cpmSampleCode.ApplicationTemplates,
+			},
+			wantErr: true,
+		},
+		{
+			name: "err: ADFName is too short",
+			give: &cpm.Code{
+				PayloadFormatIndicator: "CPV01",
+				ApplicationTemplates: []cpm.ApplicationTemplate{
+					{DataObjects: cpm.DataObjects{ADFName: []byte{0xA0}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "err: cannot pass nil pointer",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cpm.Encode(tt.give)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.want != nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Encode() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDataObjects_PAN(t *testing.T) {
+	tests := []struct {
+		name string
+		give []byte
+		want string
+	}{
+		{
+			name: "give even digits",
+			give: []byte{0x12, 0x34, 0x56, 0x78, 0x90, 0x12, 0x34, 0x58},
+			want: "1234567890123458",
+		},
+		{
+			name: "give odd digits with padding",
+			give: []byte{0x12, 0x34, 0x5F},
+			want: "12345",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			d := cpm.DataObjects{ApplicationPAN: tt.give}
+			if got := d.PAN(); got != tt.want {
+				t.Errorf("DataObjects.PAN() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
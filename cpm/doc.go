@@ -0,0 +1,2 @@
+// Package cpm implements encoding and decoding of EMV as defined in EMV QR Code Specification for Payment Systems: Consumer-Presented Mode.
+package cpm // import "go.mercari.io/go-emv-code/cpm"
@@ -0,0 +1,30 @@
+package cpm
+
+type errorCode int
+
+const (
+	// InvalidFormat represents given payload has invalid format.
+	InvalidFormat errorCode = iota + 1
+)
+
+type genericError struct {
+	code errorCode
+	msg  string
+}
+
+func (e *genericError) Error() string {
+	return e.msg
+}
+
+// InvalidFormat returns true if code is InvalidFormat.
+func (e *genericError) InvalidFormat() bool {
+	return e.code == InvalidFormat
+}
+
+// NewInvalidFormat creates a new NewInvalidFormat error.
+func NewInvalidFormat(msg string) error {
+	return &genericError{
+		code: InvalidFormat,
+		msg:  msg,
+	}
+}
@@ -0,0 +1,40 @@
+package cpm_test
+
+import (
+	"fmt"
+	"log"
+
+	"go.mercari.io/go-emv-code/cpm"
+)
+
+func ExampleDecode() {
+	c := cpm.Code{
+		PayloadFormatIndicator: cpm.PayloadFormatIndicator,
+		ApplicationTemplates: []cpm.ApplicationTemplate{
+			{
+				DataObjects: cpm.DataObjects{
+					ADFName:          []byte{0xA0, 0x00, 0x00, 0x00, 0x55, 0x55, 0x55},
+					ApplicationLabel: "Product1",
+					ApplicationPAN:   []byte{0x12, 0x34, 0x56, 0x78, 0x90, 0x12, 0x34, 0x58},
+				},
+			},
+		},
+	}
+
+	buf, err := cpm.Encode(&c)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	dst, err := cpm.Decode(buf)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(string(buf))
+	fmt.Println(dst.ApplicationTemplates[0].ApplicationLabel, dst.ApplicationTemplates[0].PAN())
+
+	// Output:
+	// hQVDUFYwMWEdTwegAAAAVVVVUAhQcm9kdWN0MVoIEjRWeJASNFg=
+	// Product1 1234567890123458
+}
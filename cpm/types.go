@@ -0,0 +1,84 @@
+package cpm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+// DataObjects represents Data Objects which may appear in both Application Template and Common Data Template.
+type DataObjects struct {
+	ADFName                  []byte `emv:"4F"`
+	ApplicationLabel         string `emv:"50"`
+	Track2EquivalentData     []byte `emv:"57"`
+	ApplicationPAN           []byte `emv:"5A"`
+	CardholderName           string `emv:"5F20"`
+	LanguagePreference       string `emv:"5F2D"`
+	IssuerURL                string `emv:"5F50"`
+	ApplicationVersionNumber []byte `emv:"9F08"`
+	TokenRequestorID         []byte `emv:"9F19"`
+	PaymentAccountReference  string `emv:"9F24"`
+	Last4DigitsOfPAN         []byte `emv:"9F25"`
+	// Others holds the remaining Data Objects in the order of appearance.
+	Others []tlv.BERTLV `emv:"*"`
+}
+
+// PAN returns ApplicationPAN as a string of digits.
+func (d *DataObjects) PAN() string {
+	return strings.TrimRight(strings.ToUpper(hex.EncodeToString(d.ApplicationPAN)), "F")
+}
+
+// ApplicationTemplate represents Data Objects for Application Template.
+type ApplicationTemplate struct {
+	DataObjects
+	ApplicationSpecificTransparentTemplate []tlv.BERTLV `emv:"63"`
+}
+
+func (a *ApplicationTemplate) Scan(b []byte) error {
+	var aa ApplicationTemplate
+	if err := tlv.NewBERDecoder(b, tagName).Decode(&aa); err != nil {
+		return err
+	}
+	*a = aa
+	return nil
+}
+
+// Tokenize turns ApplicationTemplate into bytes.
+func (a *ApplicationTemplate) Tokenize() ([]byte, error) {
+	return tokenizeTemplate(a)
+}
+
+// NullCommonDataTemplate represents Data Objects for Common Data Template that may be null.
+type NullCommonDataTemplate struct {
+	DataObjects
+	CommonDataTransparentTemplate []tlv.BERTLV `emv:"64"`
+	Valid                         bool
+}
+
+func (c *NullCommonDataTemplate) Scan(b []byte) error {
+	var cc NullCommonDataTemplate
+	if err := tlv.NewBERDecoder(b, tagName).Decode(&cc); err != nil {
+		return err
+	}
+	cc.Valid = true
+	*c = cc
+	return nil
+}
+
+// Tokenize turns NullCommonDataTemplate into bytes.
+func (c *NullCommonDataTemplate) Tokenize() ([]byte, error) {
+	if c == nil || !c.Valid {
+		return nil, nil
+	}
+	return tokenizeTemplate(c)
+}
+
+func tokenizeTemplate(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tlv.NewBEREncoder(&buf, tagName).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,216 @@
+package mpm
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+// PaymentNetwork represents a payment network which owns primitive Merchant Account Information (ID 02–25).
+type PaymentNetwork string
+
+const (
+	PaymentNetworkVisa       PaymentNetwork = "Visa"
+	PaymentNetworkMastercard PaymentNetwork = "Mastercard"
+	PaymentNetworkEMVCo      PaymentNetwork = "EMVCo"
+	PaymentNetworkDiscover   PaymentNetwork = "Discover"
+	PaymentNetworkAmex       PaymentNetwork = "Amex"
+	PaymentNetworkJCB        PaymentNetwork = "JCB"
+	PaymentNetworkUnionPay   PaymentNetwork = "UnionPay"
+)
+
+var paymentNetworkIDs = []struct {
+	from, to int
+	network  PaymentNetwork
+}{
+	{2, 3, PaymentNetworkVisa},
+	{4, 5, PaymentNetworkMastercard},
+	{6, 8, PaymentNetworkEMVCo},
+	{9, 10, PaymentNetworkDiscover},
+	{11, 12, PaymentNetworkAmex},
+	{13, 14, PaymentNetworkJCB},
+	{15, 16, PaymentNetworkUnionPay},
+	{17, 25, PaymentNetworkEMVCo},
+}
+
+// PaymentNetworkOf returns the payment network which ID is reserved for.
+// It returns false if ID is not of primitive Merchant Account Information.
+func PaymentNetworkOf(id string) (PaymentNetwork, bool) {
+	n, err := strconv.Atoi(id)
+	if err != nil || len(id) != tagLength {
+		return "", false
+	}
+	for _, v := range paymentNetworkIDs {
+		if v.from <= n && n <= v.to {
+			return v.network, true
+		}
+	}
+	return "", false
+}
+
+// PaymentNetworkAccounts returns values of primitive Merchant Account Information reserved for network in order of appearance.
+func (c *Code) PaymentNetworkAccounts(network PaymentNetwork) []string {
+	var s []string
+	for _, t := range c.MerchantAccountInformation {
+		if n, ok := PaymentNetworkOf(t.Tag); ok && n == network {
+			s = append(s, t.Value)
+		}
+	}
+	return s
+}
+
+func (c *Code) paymentNetworkAccount(network PaymentNetwork) (string, bool) {
+	s := c.PaymentNetworkAccounts(network)
+	if len(s) == 0 {
+		return "", false
+	}
+	return s[0], true
+}
+
+// VisaPAN returns the first Merchant Account Information reserved for Visa (ID 02–03).
+func (c *Code) VisaPAN() (string, bool) {
+	return c.paymentNetworkAccount(PaymentNetworkVisa)
+}
+
+// MastercardPAN returns the first Merchant Account Information reserved for Mastercard (ID 04–05).
+func (c *Code) MastercardPAN() (string, bool) {
+	return c.paymentNetworkAccount(PaymentNetworkMastercard)
+}
+
+// DiscoverPAN returns the first Merchant Account Information reserved for Discover (ID 09–10).
+func (c *Code) DiscoverPAN() (string, bool) {
+	return c.paymentNetworkAccount(PaymentNetworkDiscover)
+}
+
+// AmexPAN returns the first Merchant Account Information reserved for Amex (ID 11–12).
+func (c *Code) AmexPAN() (string, bool) {
+	return c.paymentNetworkAccount(PaymentNetworkAmex)
+}
+
+// JCBPAN returns the first Merchant Account Information reserved for JCB (ID 13–14).
+func (c *Code) JCBPAN() (string, bool) {
+	return c.paymentNetworkAccount(PaymentNetworkJCB)
+}
+
+// UnionPayPAN returns the first Merchant Account Information reserved for UnionPay (ID 15–16).
+func (c *Code) UnionPayPAN() (string, bool) {
+	return c.paymentNetworkAccount(PaymentNetworkUnionPay)
+}
+
+const (
+	merchantAccountInformationDataIDFrom  = 1
+	merchantAccountInformationDataIDTo    = 99
+	merchantAccountInformationDataTagName = "Data"
+
+	aidMinLength = 5
+	aidMaxLength = 16
+)
+
+// MerchantAccountInformationTemplate represents Data Objects for Merchant Account Information template (ID 26–51).
+// Code.MerchantAccountInformation keeps every ID 02–51 as tlv.TLV as decoded, since ID 02–25 are primitive
+// data objects of payment networks and each scheme reads its own template. The template is parsed on demand,
+// e.g. by Code.MerchantAccountInformationTemplates, counting Lengths in characters. Use ScanUnit and TokenizeUnit
+// for templates of Lengths in another unit.
+type MerchantAccountInformationTemplate struct {
+	ID                       string
+	GloballyUniqueIdentifier string    `emv:"00"`
+	Data                     []tlv.TLV `emv:"Data"` // ID 01–99, in order of appearance
+}
+
+// Tokenize turns MerchantAccountInformationTemplate into a string
+func (m *MerchantAccountInformationTemplate) Tokenize() (string, error) {
+	return m.TokenizeUnit(tlv.LengthUnitRune)
+}
+
+// TokenizeUnit turns MerchantAccountInformationTemplate into a string of Lengths in unit.
+func (m *MerchantAccountInformationTemplate) TokenizeUnit(unit tlv.LengthUnit) (string, error) {
+	if m == nil {
+		return "", nil
+	}
+	var buf strings.Builder
+	enc := tlv.NewEncoder(&buf, tagName, nil, chainTagLengthTranslators(pseudoTagTranslator(merchantAccountInformationDataTagName)))
+	enc.LengthUnit(unit)
+	if err := enc.Encode(m); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (m *MerchantAccountInformationTemplate) Scan(token []rune) error {
+	return m.ScanUnit(token, tlv.LengthUnitRune)
+}
+
+// ScanUnit parses token of Lengths in unit.
+func (m *MerchantAccountInformationTemplate) ScanUnit(token []rune, unit tlv.LengthUnit) error {
+	mm := MerchantAccountInformationTemplate{ID: m.ID}
+	translatorFunc := chainTagLengthTranslators(idRangeTranslator(merchantAccountInformationDataIDFrom, merchantAccountInformationDataIDTo, merchantAccountInformationDataTagName))
+	dec := tlv.NewDecoder(strings.NewReader(string(token)), tagName, MaxSize, tagLength, lenLength, translatorFunc)
+	dec.LengthUnit(unit)
+	if err := dec.Decode(&mm); err != nil {
+		return err
+	}
+	*m = mm
+	return nil
+}
+
+// TLV returns m as a tlv.TLV of Code.MerchantAccountInformation.
+func (m *MerchantAccountInformationTemplate) TLV() (tlv.TLV, error) {
+	v, err := m.Tokenize()
+	if err != nil {
+		return tlv.TLV{}, err
+	}
+	return tlv.New(m.ID, v)
+}
+
+// Value returns the value of the data object of tag.
+func (m *MerchantAccountInformationTemplate) Value(tag string) (string, bool) {
+	for _, t := range m.Data {
+		if t.Tag == tag {
+			return t.Value, true
+		}
+	}
+	return "", false
+}
+
+// AID returns GloballyUniqueIdentifier as an Application Identifier.
+// It returns false if GloballyUniqueIdentifier is not an AID but e.g. a reverse domain name.
+func (m *MerchantAccountInformationTemplate) AID() ([]byte, bool) {
+	b, err := hex.DecodeString(m.GloballyUniqueIdentifier)
+	if err != nil || len(b) < aidMinLength || aidMaxLength < len(b) {
+		return nil, false
+	}
+	return b, true
+}
+
+func isMerchantAccountInformationTemplateID(id string) bool {
+	n, err := strconv.Atoi(id)
+	return err == nil && len(id) == tagLength && merchantAccountInformationTemplateIDFrom <= n && n <= merchantAccountInformationIDTo
+}
+
+// MerchantAccountInformationTemplates parses Merchant Account Information templates (ID 26–51) in order of appearance.
+func (c *Code) MerchantAccountInformationTemplates() ([]MerchantAccountInformationTemplate, error) {
+	var s []MerchantAccountInformationTemplate
+	for _, t := range c.MerchantAccountInformation {
+		if !isMerchantAccountInformationTemplateID(t.Tag) {
+			continue
+		}
+		m, err := parseMerchantAccountInformationTemplate(t)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, m)
+	}
+	return s, nil
+}
+
+// parseMerchantAccountInformationTemplate parses t of a template ID. It returns FieldError of the ID if t is malformed.
+func parseMerchantAccountInformationTemplate(t tlv.TLV) (MerchantAccountInformationTemplate, error) {
+	m := MerchantAccountInformationTemplate{ID: t.Tag}
+	if err := m.Scan([]rune(t.Value)); err != nil {
+		return MerchantAccountInformationTemplate{}, NewFieldError(t.Tag, "MerchantAccountInformation", t.Value, ReasonValue, fmt.Sprintf("mpm: failed to parse MerchantAccountInformation %s: %s", t.Tag, err))
+	}
+	return m, nil
+}
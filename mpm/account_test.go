@@ -0,0 +1,478 @@
+package mpm_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+func TestPaymentNetworkOf(t *testing.T) {
+	tests := []struct {
+		id     string
+		want   mpm.PaymentNetwork
+		wantOK bool
+	}{
+		{id: "00"},
+		{id: "01"},
+		{id: "02", want: mpm.PaymentNetworkVisa, wantOK: true},
+		{id: "03", want: mpm.PaymentNetworkVisa, wantOK: true},
+		{id: "04", want: mpm.PaymentNetworkMastercard, wantOK: true},
+		{id: "05", want: mpm.PaymentNetworkMastercard, wantOK: true},
+		{id: "06", want: mpm.PaymentNetworkEMVCo, wantOK: true},
+		{id: "08", want: mpm.PaymentNetworkEMVCo, wantOK: true},
+		{id: "09", want: mpm.PaymentNetworkDiscover, wantOK: true},
+		{id: "10", want: mpm.PaymentNetworkDiscover, wantOK: true},
+		{id: "11", want: mpm.PaymentNetworkAmex, wantOK: true},
+		{id: "12", want: mpm.PaymentNetworkAmex, wantOK: true},
+		{id: "13", want: mpm.PaymentNetworkJCB, wantOK: true},
+		{id: "14", want: mpm.PaymentNetworkJCB, wantOK: true},
+		{id: "15", want: mpm.PaymentNetworkUnionPay, wantOK: true},
+		{id: "16", want: mpm.PaymentNetworkUnionPay, wantOK: true},
+		{id: "17", want: mpm.PaymentNetworkEMVCo, wantOK: true},
+		{id: "25", want: mpm.PaymentNetworkEMVCo, wantOK: true},
+		{id: "26"},
+		{id: "51"},
+		{id: "2"},
+		{id: "002"},
+		{id: "-2"},
+		{id: "0A"},
+		{id: ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.id, func(t *testing.T) {
+			got, ok := mpm.PaymentNetworkOf(tt.id)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("PaymentNetworkOf(%q) = %q, %t, want %q, %t", tt.id, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCode_PaymentNetworkAccounts(t *testing.T) {
+	c := mpm.Code{
+		MerchantAccountInformation: []tlv.TLV{
+			{Tag: "03", Length: "16", Value: "4000123456789013"},
+			{Tag: "02", Length: "16", Value: "4000123456789012"},
+			{Tag: "05", Length: "16", Value: "5100123456789015"},
+			{Tag: "08", Length: "08", Value: "12345678"},
+			{Tag: "17", Length: "08", Value: "87654321"},
+			{Tag: "26", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+		},
+	}
+	tests := []struct {
+		network mpm.PaymentNetwork
+		want    []string
+	}{
+		{network: mpm.PaymentNetworkVisa, want: []string{"4000123456789013", "4000123456789012"}},
+		{network: mpm.PaymentNetworkMastercard, want: []string{"5100123456789015"}},
+		{network: mpm.PaymentNetworkEMVCo, want: []string{"12345678", "87654321"}},
+		{network: mpm.PaymentNetworkJCB},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(string(tt.network), func(t *testing.T) {
+			if got := c.PaymentNetworkAccounts(tt.network); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Code.PaymentNetworkAccounts(%s) = %q, want %q", tt.network, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCode_PAN(t *testing.T) {
+	tests := []struct {
+		name   string
+		give   *mpm.Code
+		pan    func(*mpm.Code) (string, bool)
+		want   string
+		wantOK bool
+	}{
+		{
+			name: "Visa of ID 02",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "02", Length: "16", Value: "4000123456789012"},
+				},
+			},
+			pan:    (*mpm.Code).VisaPAN,
+			want:   "4000123456789012",
+			wantOK: true,
+		},
+		{
+			name: "Visa of ID 03 following Mastercard",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "5100123456789015"},
+					{Tag: "03", Length: "16", Value: "4000123456789013"},
+				},
+			},
+			pan:    (*mpm.Code).VisaPAN,
+			want:   "4000123456789013",
+			wantOK: true,
+		},
+		{
+			name: "Visa of the first ID",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "03", Length: "16", Value: "4000123456789013"},
+					{Tag: "02", Length: "16", Value: "4000123456789012"},
+				},
+			},
+			pan:    (*mpm.Code).VisaPAN,
+			want:   "4000123456789013",
+			wantOK: true,
+		},
+		{
+			name: "Mastercard of ID 04",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "5100123456789014"},
+				},
+			},
+			pan:    (*mpm.Code).MastercardPAN,
+			want:   "5100123456789014",
+			wantOK: true,
+		},
+		{
+			name: "Mastercard of ID 05",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "05", Length: "16", Value: "5100123456789015"},
+				},
+			},
+			pan:    (*mpm.Code).MastercardPAN,
+			want:   "5100123456789015",
+			wantOK: true,
+		},
+		{
+			name: "no Mastercard of ID 03 and 06",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "03", Length: "16", Value: "4000123456789013"},
+					{Tag: "06", Length: "08", Value: "12345678"},
+				},
+			},
+			pan: (*mpm.Code).MastercardPAN,
+		},
+		{
+			name: "Discover of ID 09",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "09", Length: "16", Value: "6011123456789019"},
+				},
+			},
+			pan:    (*mpm.Code).DiscoverPAN,
+			want:   "6011123456789019",
+			wantOK: true,
+		},
+		{
+			name: "Discover of ID 10",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "10", Length: "16", Value: "6011123456789010"},
+				},
+			},
+			pan:    (*mpm.Code).DiscoverPAN,
+			want:   "6011123456789010",
+			wantOK: true,
+		},
+		{
+			name: "no Discover of ID 08 and 11",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "08", Length: "08", Value: "12345678"},
+					{Tag: "11", Length: "15", Value: "371234567890111"},
+				},
+			},
+			pan: (*mpm.Code).DiscoverPAN,
+		},
+		{
+			name: "Amex of ID 11",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "11", Length: "15", Value: "371234567890111"},
+				},
+			},
+			pan:    (*mpm.Code).AmexPAN,
+			want:   "371234567890111",
+			wantOK: true,
+		},
+		{
+			name: "Amex of ID 12",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "12", Length: "15", Value: "371234567890112"},
+				},
+			},
+			pan:    (*mpm.Code).AmexPAN,
+			want:   "371234567890112",
+			wantOK: true,
+		},
+		{
+			name: "no Amex of ID 10 and 13",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "10", Length: "16", Value: "6011123456789010"},
+					{Tag: "13", Length: "16", Value: "3530123456789013"},
+				},
+			},
+			pan: (*mpm.Code).AmexPAN,
+		},
+		{
+			name: "JCB of ID 13",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "13", Length: "16", Value: "3530123456789013"},
+				},
+			},
+			pan:    (*mpm.Code).JCBPAN,
+			want:   "3530123456789013",
+			wantOK: true,
+		},
+		{
+			name: "JCB of ID 14",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "14", Length: "16", Value: "3530123456789014"},
+				},
+			},
+			pan:    (*mpm.Code).JCBPAN,
+			want:   "3530123456789014",
+			wantOK: true,
+		},
+		{
+			name: "no JCB of ID 12 and 15",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "12", Length: "15", Value: "371234567890112"},
+					{Tag: "15", Length: "16", Value: "6212345678901215"},
+				},
+			},
+			pan: (*mpm.Code).JCBPAN,
+		},
+		{
+			name: "UnionPay of ID 15",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "15", Length: "16", Value: "6212345678901215"},
+				},
+			},
+			pan:    (*mpm.Code).UnionPayPAN,
+			want:   "6212345678901215",
+			wantOK: true,
+		},
+		{
+			name: "UnionPay of ID 16",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "16", Length: "16", Value: "6212345678901216"},
+				},
+			},
+			pan:    (*mpm.Code).UnionPayPAN,
+			want:   "6212345678901216",
+			wantOK: true,
+		},
+		{
+			name: "no UnionPay of ID 14 and 17",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "14", Length: "16", Value: "3530123456789014"},
+					{Tag: "17", Length: "08", Value: "12345678"},
+				},
+			},
+			pan: (*mpm.Code).UnionPayPAN,
+		},
+		{
+			name: "no Visa of ID 01 and 26",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "01", Length: "16", Value: "4000123456789011"},
+					{Tag: "26", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+			},
+			pan: (*mpm.Code).VisaPAN,
+		},
+		{
+			name: "no Visa without MerchantAccountInformation",
+			give: &mpm.Code{},
+			pan:  (*mpm.Code).VisaPAN,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.pan(tt.give)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("PAN() = %s, %t, want %s, %t", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestMerchantAccountInformationTemplate_AID(t *testing.T) {
+	tests := []struct {
+		name   string
+		guid   string
+		want   string
+		wantOK bool
+	}{
+		{name: "RID and PIX", guid: "A0000006770101", want: "a0000006770101", wantOK: true},
+		{name: "lower case", guid: "d15600000000", want: "d15600000000", wantOK: true},
+		{name: "5 bytes", guid: "A000000677", want: "a000000677", wantOK: true},
+		{name: "16 bytes", guid: "A0000006770101110000000000000000", want: "a0000006770101110000000000000000", wantOK: true},
+		{name: "4 bytes", guid: "A0000006"},
+		{name: "17 bytes", guid: "A000000677010111000000000000000000"},
+		{name: "odd length", guid: "A00000067"},
+		{name: "reverse domain name", guid: "jp.or.paymentsjapan"},
+		{name: "empty"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			m := mpm.MerchantAccountInformationTemplate{ID: "26", GloballyUniqueIdentifier: tt.guid}
+			got, ok := m.AID()
+			if hex.EncodeToString(got) != tt.want || ok != tt.wantOK {
+				t.Errorf("MerchantAccountInformationTemplate.AID() = %x, %t, want %s, %t", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCode_MerchantAccountInformationTemplates(t *testing.T) {
+	tests := []struct {
+		name       string
+		give       *mpm.Code
+		want       []mpm.MerchantAccountInformationTemplate
+		wantErr    bool
+		wantID     string
+		wantReason mpm.Reason
+	}{
+		{
+			name: "pass: templates of ID 26 and 51 in order of appearance",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "51", Length: "28", Value: "0012D15600000001030812345678"},
+					{Tag: "04", Length: "16", Value: "5100123456789014"},
+					{Tag: "25", Length: "08", Value: "12345678"},
+					{Tag: "26", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+			},
+			want: []mpm.MerchantAccountInformationTemplate{
+				{
+					ID:                       "51",
+					GloballyUniqueIdentifier: "D15600000001",
+					Data: []tlv.TLV{
+						{Tag: "03", Length: "08", Value: "12345678"},
+					},
+				},
+				{
+					ID:                       "26",
+					GloballyUniqueIdentifier: "D15600000000",
+					Data: []tlv.TLV{
+						{Tag: "05", Length: "10", Value: "A93FO3230Q"},
+					},
+				},
+			},
+		},
+		{
+			name: "pass: without templates",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "02", Length: "16", Value: "4000123456789012"},
+				},
+			},
+		},
+		{
+			name: "err: template is too short",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+					{Tag: "27", Length: "06", Value: "001212"},
+				},
+			},
+			wantErr:    true,
+			wantID:     "27",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: template has a non numeric length",
+			give: &mpm.Code{
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "20", Value: "0012D1560000000001AB"},
+				},
+			},
+			wantErr:    true,
+			wantID:     "26",
+			wantReason: mpm.ReasonValue,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.MerchantAccountInformationTemplates()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Code.MerchantAccountInformationTemplates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				var fe *mpm.FieldError
+				if !errors.As(err, &fe) || fe.ID != tt.wantID || fe.Reason != tt.wantReason {
+					t.Errorf("Code.MerchantAccountInformationTemplates() error = %#v, want ID %s of %s", err, tt.wantID, tt.wantReason)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Code.MerchantAccountInformationTemplates() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMerchantAccountInformationTemplate_TokenizeUnit(t *testing.T) {
+	tests := []struct {
+		name string
+		unit tlv.LengthUnit
+		want string
+	}{
+		{
+			name: "runes",
+			unit: tlv.LengthUnitRune,
+			want: "0012D156000000000102東京",
+		},
+		{
+			name: "bytes",
+			unit: tlv.LengthUnitByte,
+			want: "0012D156000000000106東京",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mpm.MerchantAccountInformationTemplate{
+				ID:                       "26",
+				GloballyUniqueIdentifier: "D15600000000",
+				Data: []tlv.TLV{
+					{Tag: "01", Value: "東京"},
+				},
+			}
+			got, err := m.TokenizeUnit(tt.unit)
+			if err != nil {
+				t.Fatalf("MerchantAccountInformationTemplate.TokenizeUnit() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("MerchantAccountInformationTemplate.TokenizeUnit() = %s, want %s", got, tt.want)
+			}
+
+			dst := mpm.MerchantAccountInformationTemplate{ID: "26"}
+			if err := dst.ScanUnit([]rune(got), tt.unit); err != nil {
+				t.Fatalf("MerchantAccountInformationTemplate.ScanUnit() error = %v", err)
+			}
+			if v, _ := dst.Value("01"); dst.GloballyUniqueIdentifier != "D15600000000" || v != "東京" {
+				t.Errorf("MerchantAccountInformationTemplate.ScanUnit() = %+v, want 東京 of D15600000000", dst)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package mpm
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.mercari.io/go-emv-code/refdata"
+)
+
+// Amount represents a monetary amount in the minor unit of a currency, e.g. 12345 of Exponent 2 for "123.45".
+type Amount struct {
+	// MinorUnits is the amount in the minor unit of the currency.
+	MinorUnits int64
+	// Exponent is the number of digits after the decimal mark in the currency, e.g. 0 for JPY and 2 for USD.
+	Exponent int
+}
+
+const amountMaxLength = 13
+
+// ErrAmountNotFound represents the Code has no data object of the amount.
+var ErrAmountNotFound = errors.New("mpm: amount not found")
+
+// ParseAmount parses s formatted as Transaction Amount in the currency of exponent.
+// s should consist of digits and an optional '.' as the decimal mark, without sign and thousands separators,
+// be at most 13 characters and have at most exponent digits after the decimal mark.
+func ParseAmount(s string, exponent int) (Amount, error) {
+	if exponent < 0 {
+		return Amount{}, fmt.Errorf("mpm: invalid exponent %d", exponent)
+	}
+	if s == "" || amountMaxLength < len(s) {
+		return Amount{}, fmt.Errorf("mpm: length of amount %q should be between 1 and %d", s, amountMaxLength)
+	}
+	if !isDecimal(s) {
+		return Amount{}, fmt.Errorf("mpm: amount %q should be a decimal number", s)
+	}
+	integer, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		integer, fraction = s[:i], s[i+1:]
+	}
+	if exponent < len(fraction) {
+		return Amount{}, fmt.Errorf("mpm: amount %q has more than %d decimals", s, exponent)
+	}
+	digits := integer + fraction + strings.Repeat("0", exponent-len(fraction))
+	v, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Amount{}, fmt.Errorf("mpm: amount %q is out of range", s)
+	}
+	return Amount{MinorUnits: v, Exponent: exponent}, nil
+}
+
+// String formats a as Transaction Amount with exactly Exponent digits after the decimal mark, e.g. "123.45" and "100".
+func (a Amount) String() string {
+	digits := strconv.FormatInt(a.MinorUnits, 10)
+	neg := a.MinorUnits < 0
+	if neg {
+		digits = digits[1:]
+	}
+	if a.Exponent > 0 {
+		if len(digits) <= a.Exponent {
+			digits = strings.Repeat("0", a.Exponent-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-a.Exponent] + "." + digits[len(digits)-a.Exponent:]
+	}
+	if neg {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Valid reports whether a can be represented as Transaction Amount.
+func (a Amount) Valid() bool {
+	return 0 <= a.MinorUnits && 0 <= a.Exponent && len(a.String()) <= amountMaxLength
+}
+
+// ParseTransactionAmount parses Transaction Amount in the minor unit of Transaction Currency.
+// It returns ErrAmountNotFound if Transaction Amount is absent.
+func (c *Code) ParseTransactionAmount() (Amount, error) {
+	return c.parseAmount(c.TransactionAmount, "TransactionAmount")
+}
+
+// ParseConvenienceFeeFixed parses Value of Convenience Fee Fixed in the minor unit of Transaction Currency.
+// It returns ErrAmountNotFound if Value of Convenience Fee Fixed is absent.
+func (c *Code) ParseConvenienceFeeFixed() (Amount, error) {
+	return c.parseAmount(c.ValueOfConvenienceFeeFixed, "ValueOfConvenienceFeeFixed")
+}
+
+// percentageExponent is the number of digits after the decimal mark in Value of Convenience Fee Percentage.
+const percentageExponent = 2
+
+// ParseConvenienceFeePercentage parses Value of Convenience Fee Percentage in hundredths of a percent,
+// e.g. 350 of Exponent 2 for "3.5". The percentage should be between 00.01 and 99.99.
+// It returns ErrAmountNotFound if Value of Convenience Fee Percentage is absent.
+func (c *Code) ParseConvenienceFeePercentage() (Amount, error) {
+	v := c.ValueOfConvenienceFeePercentage
+	if !v.Valid {
+		return Amount{}, fmt.Errorf("%w: %s", ErrAmountNotFound, "ValueOfConvenienceFeePercentage")
+	}
+	p, err := ParseAmount(v.String, percentageExponent)
+	if err != nil || valueOfConvenienceFeePercentLen < len(v.String) || p.MinorUnits <= 0 || 100*100 <= p.MinorUnits {
+		return Amount{}, fmt.Errorf("mpm: ValueOfConvenienceFeePercentage %q should be a decimal number between 00.01 and 99.99", v.String)
+	}
+	return p, nil
+}
+
+func (c *Code) parseAmount(v NullString, field string) (Amount, error) {
+	if !v.Valid {
+		return Amount{}, fmt.Errorf("%w: %s", ErrAmountNotFound, field)
+	}
+	exponent, ok := CurrencyExponent(c.TransactionCurrency)
+	if !ok {
+		return Amount{}, fmt.Errorf("mpm: unknown TransactionCurrency %q", c.TransactionCurrency)
+	}
+	return ParseAmount(v.String, exponent)
+}
+
+// CurrencyExponent returns the number of digits after the decimal mark in the currency of ISO 4217 numeric code.
+func CurrencyExponent(currency string) (int, bool) {
+	c, ok := refdata.CurrencyByNumeric(currency)
+	return c.Exponent, ok
+}
+
+// TotalAmount returns Transaction Amount plus the tip or the convenience fee by Tip or Convenience Indicator.
+// tip is the amount the consumer entered when prompted, and should be nil unless the indicator is 01.
+// A percentage convenience fee, see ParseConvenienceFeePercentage, is rounded half up to the minor unit of Transaction Currency.
+// It returns ErrAmountNotFound if Transaction Amount is absent, see TotalAmountFor for such codes.
+func (c *Code) TotalAmount(tip *Amount) (Amount, error) {
+	amount, err := c.ParseTransactionAmount()
+	if err != nil {
+		return Amount{}, err
+	}
+	return c.totalAmount(amount, tip)
+}
+
+// TotalAmountFor returns amount the consumer entered plus the tip or the convenience fee by Tip or Convenience Indicator,
+// for the Code without Transaction Amount, typically a static one. See TotalAmount for tip and the convenience fee.
+// amount should be in the minor unit of Transaction Currency.
+func (c *Code) TotalAmountFor(amount Amount, tip *Amount) (Amount, error) {
+	if c.TransactionAmount.Valid {
+		return Amount{}, NewFieldError("54", "TransactionAmount", c.TransactionAmount.String, ReasonUnexpected, "mpm: TransactionAmount should be absent for the amount the consumer entered")
+	}
+	exponent, ok := CurrencyExponent(c.TransactionCurrency)
+	if !ok {
+		return Amount{}, fmt.Errorf("mpm: unknown TransactionCurrency %q", c.TransactionCurrency)
+	}
+	if amount.Exponent != exponent || !amount.Valid() {
+		return Amount{}, fmt.Errorf("mpm: amount %s should be a non-negative amount of exponent %d", amount, exponent)
+	}
+	return c.totalAmount(amount, tip)
+}
+
+func (c *Code) totalAmount(amount Amount, tip *Amount) (Amount, error) {
+	if c.TipOrConvenienceIndicator != TipOrConvenienceIndicatorFixed && c.ValueOfConvenienceFeeFixed.Valid {
+		return Amount{}, NewFieldError("56", "ValueOfConvenienceFeeFixed", c.ValueOfConvenienceFeeFixed.String, ReasonUnexpected, fmt.Sprintf("mpm: ValueOfConvenienceFeeFixed should be represented only if TipOrConvenienceIndicator is %s", TipOrConvenienceIndicatorFixed))
+	}
+	if c.TipOrConvenienceIndicator != TipOrConvenienceIndicatorPercentage && c.ValueOfConvenienceFeePercentage.Valid {
+		return Amount{}, NewFieldError("57", "ValueOfConvenienceFeePercentage", c.ValueOfConvenienceFeePercentage.String, ReasonUnexpected, fmt.Sprintf("mpm: ValueOfConvenienceFeePercentage should be represented only if TipOrConvenienceIndicator is %s", TipOrConvenienceIndicatorPercentage))
+	}
+	if c.TipOrConvenienceIndicator != TipOrConvenienceIndicatorPrompt && tip != nil {
+		return Amount{}, fmt.Errorf("mpm: tip is not acceptable unless TipOrConvenienceIndicator is %s", TipOrConvenienceIndicatorPrompt)
+	}
+
+	var extra Amount
+	switch c.TipOrConvenienceIndicator {
+	case "":
+		return amount, nil
+	case TipOrConvenienceIndicatorPrompt:
+		if tip == nil {
+			return amount, nil
+		}
+		if tip.Exponent != amount.Exponent || !tip.Valid() {
+			return Amount{}, fmt.Errorf("mpm: tip %s should be a non-negative amount of exponent %d", tip, amount.Exponent)
+		}
+		extra = *tip
+	case TipOrConvenienceIndicatorFixed:
+		fee, err := c.ParseConvenienceFeeFixed()
+		if errors.Is(err, ErrAmountNotFound) {
+			return Amount{}, NewFieldError("56", "ValueOfConvenienceFeeFixed", "", ReasonMissing, fmt.Sprintf("mpm: ValueOfConvenienceFeeFixed should be represented if TipOrConvenienceIndicator is %s", TipOrConvenienceIndicatorFixed))
+		}
+		if err != nil {
+			return Amount{}, NewFieldError("56", "ValueOfConvenienceFeeFixed", c.ValueOfConvenienceFeeFixed.String, ReasonValue, err.Error())
+		}
+		extra = fee
+	case TipOrConvenienceIndicatorPercentage:
+		p, err := c.ParseConvenienceFeePercentage()
+		if errors.Is(err, ErrAmountNotFound) {
+			return Amount{}, NewFieldError("57", "ValueOfConvenienceFeePercentage", "", ReasonMissing, fmt.Sprintf("mpm: ValueOfConvenienceFeePercentage should be represented if TipOrConvenienceIndicator is %s", TipOrConvenienceIndicatorPercentage))
+		}
+		if err != nil {
+			return Amount{}, NewFieldError("57", "ValueOfConvenienceFeePercentage", c.ValueOfConvenienceFeePercentage.String, ReasonValue, err.Error())
+		}
+		// p.MinorUnits is in hundredths of a percent.
+		extra = Amount{MinorUnits: (amount.MinorUnits*p.MinorUnits + 5000) / 10000, Exponent: amount.Exponent}
+	default:
+		return Amount{}, NewFieldError("55", "TipOrConvenienceIndicator", string(c.TipOrConvenienceIndicator), ReasonValue, "mpm: TipOrConvenienceIndicator should be one of 01, 02 and 03")
+	}
+
+	total := Amount{MinorUnits: amount.MinorUnits + extra.MinorUnits, Exponent: amount.Exponent}
+	if !total.Valid() {
+		return Amount{}, fmt.Errorf("mpm: total amount %s exceeds %d characters", total, amountMaxLength)
+	}
+	return total, nil
+}
@@ -0,0 +1,476 @@
+package mpm_test
+
+import (
+	"errors"
+	"testing"
+
+	"go.mercari.io/go-emv-code/mpm"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		exponent int
+		want     mpm.Amount
+		wantErr  bool
+	}{
+		{
+			name:     "pass",
+			s:        "123.45",
+			exponent: 2,
+			want:     mpm.Amount{MinorUnits: 12345, Exponent: 2},
+		},
+		{
+			name:     "pass: fewer decimals",
+			s:        "123.4",
+			exponent: 3,
+			want:     mpm.Amount{MinorUnits: 123400, Exponent: 3},
+		},
+		{
+			name:     "pass: no decimal mark",
+			s:        "100",
+			exponent: 2,
+			want:     mpm.Amount{MinorUnits: 10000, Exponent: 2},
+		},
+		{
+			name:     "pass: trailing decimal mark",
+			s:        "100.",
+			exponent: 0,
+			want:     mpm.Amount{MinorUnits: 100, Exponent: 0},
+		},
+		{
+			name:     "pass: leading decimal mark",
+			s:        ".5",
+			exponent: 2,
+			want:     mpm.Amount{MinorUnits: 50, Exponent: 2},
+		},
+		{
+			name:     "err: too many decimals",
+			s:        "100.5",
+			exponent: 0,
+			wantErr:  true,
+		},
+		{
+			name:     "err: thousands separator",
+			s:        "1,000",
+			exponent: 2,
+			wantErr:  true,
+		},
+		{
+			name:     "err: sign",
+			s:        "-1",
+			exponent: 2,
+			wantErr:  true,
+		},
+		{
+			name:     "err: too long",
+			s:        "12345678901234",
+			exponent: 2,
+			wantErr:  true,
+		},
+		{
+			name:     "err: empty",
+			exponent: 2,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mpm.ParseAmount(tt.s, tt.exponent)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAmount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseAmount() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmount_String(t *testing.T) {
+	tests := []struct {
+		amount mpm.Amount
+		want   string
+	}{
+		{amount: mpm.Amount{MinorUnits: 12345, Exponent: 2}, want: "123.45"},
+		{amount: mpm.Amount{MinorUnits: 5, Exponent: 2}, want: "0.05"},
+		{amount: mpm.Amount{MinorUnits: 100, Exponent: 0}, want: "100"},
+		{amount: mpm.Amount{MinorUnits: 1000, Exponent: 3}, want: "1.000"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.amount.String(); got != tt.want {
+				t.Errorf("Amount.String() = %s, want %s", got, tt.want)
+			}
+			if !tt.amount.Valid() {
+				t.Errorf("Amount.Valid() = false, want true")
+			}
+		})
+	}
+}
+
+func TestCode_ParseTransactionAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		amount   mpm.NullString
+		want     mpm.Amount
+		wantErr  error
+	}{
+		{
+			name:     "pass: JPY",
+			currency: "392",
+			amount:   mpm.NullString{String: "100", Valid: true},
+			want:     mpm.Amount{MinorUnits: 100, Exponent: 0},
+		},
+		{
+			name:     "pass: KWD",
+			currency: "414",
+			amount:   mpm.NullString{String: "1.5", Valid: true},
+			want:     mpm.Amount{MinorUnits: 1500, Exponent: 3},
+		},
+		{
+			name:     "err: absent",
+			currency: "392",
+			wantErr:  mpm.ErrAmountNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c := mpm.Code{TransactionCurrency: tt.currency, TransactionAmount: tt.amount}
+			got, err := c.ParseTransactionAmount()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Code.ParseTransactionAmount() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Code.ParseTransactionAmount() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	c := mpm.Code{TransactionCurrency: "392", TransactionAmount: mpm.NullString{String: "100.5", Valid: true}}
+	if _, err := c.ParseTransactionAmount(); err == nil {
+		t.Error("Code.ParseTransactionAmount() of 100.5 JPY error = nil, want error")
+	}
+}
+
+func TestCode_ParseConvenienceFeePercentage(t *testing.T) {
+	tests := []struct {
+		name       string
+		percentage mpm.NullString
+		want       mpm.Amount
+		wantErr    bool
+		wantErrIs  error
+	}{
+		{
+			name:       "pass",
+			percentage: mpm.NullString{String: "3.5", Valid: true},
+			want:       mpm.Amount{MinorUnits: 350, Exponent: 2},
+		},
+		{
+			name:       "pass: minimum",
+			percentage: mpm.NullString{String: "00.01", Valid: true},
+			want:       mpm.Amount{MinorUnits: 1, Exponent: 2},
+		},
+		{
+			name:       "pass: maximum",
+			percentage: mpm.NullString{String: "99.99", Valid: true},
+			want:       mpm.Amount{MinorUnits: 9999, Exponent: 2},
+		},
+		{
+			name:      "err: absent",
+			wantErr:   true,
+			wantErrIs: mpm.ErrAmountNotFound,
+		},
+		{
+			name:       "err: zero",
+			percentage: mpm.NullString{String: "0", Valid: true},
+			wantErr:    true,
+		},
+		{
+			name:       "err: 100 percent",
+			percentage: mpm.NullString{String: "100", Valid: true},
+			wantErr:    true,
+		},
+		{
+			name:       "err: more than 2 decimals",
+			percentage: mpm.NullString{String: "3.125", Valid: true},
+			wantErr:    true,
+		},
+		{
+			name:       "err: longer than 5 characters",
+			percentage: mpm.NullString{String: "03.500", Valid: true},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c := mpm.Code{TipOrConvenienceIndicator: mpm.TipOrConvenienceIndicatorPercentage, ValueOfConvenienceFeePercentage: tt.percentage}
+			got, err := c.ParseConvenienceFeePercentage()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Code.ParseConvenienceFeePercentage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("Code.ParseConvenienceFeePercentage() error = %v, want %v", err, tt.wantErrIs)
+			}
+			if got != tt.want {
+				t.Errorf("Code.ParseConvenienceFeePercentage() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCode_TotalAmount(t *testing.T) {
+	amount := func(v int64, exponent int) *mpm.Amount {
+		return &mpm.Amount{MinorUnits: v, Exponent: exponent}
+	}
+	tests := []struct {
+		name       string
+		currency   string
+		amount     string
+		indicator  mpm.TipOrConvenienceIndicator
+		fixed      mpm.NullString
+		percentage mpm.NullString
+		tip        *mpm.Amount
+		want       mpm.Amount
+		wantID     string
+		wantErr    bool
+	}{
+		{
+			name:     "pass: no indicator",
+			currency: "156",
+			amount:   "23.72",
+			want:     mpm.Amount{MinorUnits: 2372, Exponent: 2},
+		},
+		{
+			name:      "pass: prompt with tip",
+			currency:  "156",
+			amount:    "23.72",
+			indicator: mpm.TipOrConvenienceIndicatorPrompt,
+			tip:       amount(300, 2),
+			want:      mpm.Amount{MinorUnits: 2672, Exponent: 2},
+		},
+		{
+			name:      "pass: prompt without tip",
+			currency:  "392",
+			amount:    "1000",
+			indicator: mpm.TipOrConvenienceIndicatorPrompt,
+			want:      mpm.Amount{MinorUnits: 1000, Exponent: 0},
+		},
+		{
+			name:      "pass: fixed",
+			currency:  "392",
+			amount:    "1000",
+			indicator: mpm.TipOrConvenienceIndicatorFixed,
+			fixed:     mpm.NullString{String: "110", Valid: true},
+			want:      mpm.Amount{MinorUnits: 1110, Exponent: 0},
+		},
+		{
+			name:       "pass: percentage rounded half up",
+			currency:   "156",
+			amount:     "23.72",
+			indicator:  mpm.TipOrConvenienceIndicatorPercentage,
+			percentage: mpm.NullString{String: "3.25", Valid: true},
+			want:       mpm.Amount{MinorUnits: 2449, Exponent: 2}, // 0.7709 is rounded to 0.77
+		},
+		{
+			name:       "pass: percentage of JPY",
+			currency:   "392",
+			amount:     "150",
+			indicator:  mpm.TipOrConvenienceIndicatorPercentage,
+			percentage: mpm.NullString{String: "3", Valid: true},
+			want:       mpm.Amount{MinorUnits: 155, Exponent: 0}, // 4.5 is rounded to 5
+		},
+		{
+			name:      "err: tip of another exponent",
+			currency:  "392",
+			amount:    "1000",
+			indicator: mpm.TipOrConvenienceIndicatorPrompt,
+			tip:       amount(100, 2),
+			wantErr:   true,
+		},
+		{
+			name:     "err: tip without prompt",
+			currency: "392",
+			amount:   "1000",
+			tip:      amount(100, 0),
+			wantErr:  true,
+		},
+		{
+			name:      "err: fixed fee is missing",
+			currency:  "392",
+			amount:    "1000",
+			indicator: mpm.TipOrConvenienceIndicatorFixed,
+			wantID:    "56",
+			wantErr:   true,
+		},
+		{
+			name:      "err: fixed fee has too many decimals",
+			currency:  "392",
+			amount:    "1000",
+			indicator: mpm.TipOrConvenienceIndicatorFixed,
+			fixed:     mpm.NullString{String: "1.5", Valid: true},
+			wantID:    "56",
+			wantErr:   true,
+		},
+		{
+			name:      "err: percentage is missing",
+			currency:  "156",
+			amount:    "23.72",
+			indicator: mpm.TipOrConvenienceIndicatorPercentage,
+			wantID:    "57",
+			wantErr:   true,
+		},
+		{
+			name:       "err: percentage is out of range",
+			currency:   "156",
+			amount:     "23.72",
+			indicator:  mpm.TipOrConvenienceIndicatorPercentage,
+			percentage: mpm.NullString{String: "100", Valid: true},
+			wantID:     "57",
+			wantErr:    true,
+		},
+		{
+			name:       "err: percentage with fixed indicator",
+			currency:   "392",
+			amount:     "1000",
+			indicator:  mpm.TipOrConvenienceIndicatorFixed,
+			fixed:      mpm.NullString{String: "110", Valid: true},
+			percentage: mpm.NullString{String: "3", Valid: true},
+			wantID:     "57",
+			wantErr:    true,
+		},
+		{
+			name:     "err: TransactionAmount is absent",
+			currency: "392",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c := mpm.Code{
+				TransactionCurrency:             tt.currency,
+				TransactionAmount:               mpm.NullString{String: tt.amount, Valid: tt.amount != ""},
+				TipOrConvenienceIndicator:       tt.indicator,
+				ValueOfConvenienceFeeFixed:      tt.fixed,
+				ValueOfConvenienceFeePercentage: tt.percentage,
+			}
+			got, err := c.TotalAmount(tt.tip)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Code.TotalAmount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantID != "" {
+				var fe *mpm.FieldError
+				if !errors.As(err, &fe) || fe.ID != tt.wantID {
+					t.Errorf("Code.TotalAmount() error = %#v, want ID %s", err, tt.wantID)
+				}
+			}
+			if got != tt.want {
+				t.Errorf("Code.TotalAmount() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCode_TotalAmountFor(t *testing.T) {
+	tests := []struct {
+		name      string
+		currency  string
+		amount    string
+		indicator mpm.TipOrConvenienceIndicator
+		fixed     mpm.NullString
+		give      mpm.Amount
+		tip       *mpm.Amount
+		want      mpm.Amount
+		wantID    string
+		wantErr   bool
+	}{
+		{
+			name:     "pass: entered amount",
+			currency: "392",
+			give:     mpm.Amount{MinorUnits: 1000, Exponent: 0},
+			want:     mpm.Amount{MinorUnits: 1000, Exponent: 0},
+		},
+		{
+			name:      "pass: entered amount with tip",
+			currency:  "156",
+			indicator: mpm.TipOrConvenienceIndicatorPrompt,
+			give:      mpm.Amount{MinorUnits: 2372, Exponent: 2},
+			tip:       &mpm.Amount{MinorUnits: 300, Exponent: 2},
+			want:      mpm.Amount{MinorUnits: 2672, Exponent: 2},
+		},
+		{
+			name:      "pass: entered amount with fixed fee",
+			currency:  "392",
+			indicator: mpm.TipOrConvenienceIndicatorFixed,
+			fixed:     mpm.NullString{String: "110", Valid: true},
+			give:      mpm.Amount{MinorUnits: 1000, Exponent: 0},
+			want:      mpm.Amount{MinorUnits: 1110, Exponent: 0},
+		},
+		{
+			name:     "err: TransactionAmount is present",
+			currency: "392",
+			amount:   "1000",
+			give:     mpm.Amount{MinorUnits: 1000, Exponent: 0},
+			wantID:   "54",
+			wantErr:  true,
+		},
+		{
+			name:     "err: entered amount of another exponent",
+			currency: "392",
+			give:     mpm.Amount{MinorUnits: 1000, Exponent: 2},
+			wantErr:  true,
+		},
+		{
+			name:     "err: negative entered amount",
+			currency: "392",
+			give:     mpm.Amount{MinorUnits: -1000, Exponent: 0},
+			wantErr:  true,
+		},
+		{
+			name:     "err: unknown currency",
+			currency: "999",
+			give:     mpm.Amount{MinorUnits: 1000, Exponent: 0},
+			wantErr:  true,
+		},
+		{
+			name:      "err: fixed fee is missing",
+			currency:  "392",
+			indicator: mpm.TipOrConvenienceIndicatorFixed,
+			give:      mpm.Amount{MinorUnits: 1000, Exponent: 0},
+			wantID:    "56",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c := mpm.Code{
+				PointOfInitiationMethod:    mpm.PointOfInitiationMethodStatic,
+				TransactionCurrency:        tt.currency,
+				TransactionAmount:          mpm.NullString{String: tt.amount, Valid: tt.amount != ""},
+				TipOrConvenienceIndicator:  tt.indicator,
+				ValueOfConvenienceFeeFixed: tt.fixed,
+			}
+			got, err := c.TotalAmountFor(tt.give, tt.tip)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Code.TotalAmountFor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantID != "" {
+				var fe *mpm.FieldError
+				if !errors.As(err, &fe) || fe.ID != tt.wantID {
+					t.Errorf("Code.TotalAmountFor() error = %#v, want ID %s", err, tt.wantID)
+				}
+			}
+			if got != tt.want {
+				t.Errorf("Code.TotalAmountFor() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
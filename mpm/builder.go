@@ -0,0 +1,201 @@
+package mpm
+
+import (
+	"fmt"
+
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+// Builder builds a Code step by step.
+// IDs of templates are assigned in order of the calls and lengths are computed.
+// Problems are reported by Build.
+type Builder struct {
+	c    Code
+	errs []error
+
+	merchantAccountInformationTemplates int
+	unreservedTemplates                 int
+}
+
+// NewBuilder returns a new Builder of a Code of PayloadFormatIndicator "01".
+func NewBuilder() *Builder {
+	return &Builder{
+		c: Code{PayloadFormatIndicator: "01"},
+	}
+}
+
+// PointOfInitiationMethod sets Point of Initiation Method.
+func (b *Builder) PointOfInitiationMethod(p PointOfInitiationMethod) *Builder {
+	b.c.PointOfInitiationMethod = p
+	return b
+}
+
+// PaymentNetworkAccount adds primitive Merchant Account Information of id (02–25) reserved for a payment network.
+func (b *Builder) PaymentNetworkAccount(id, value string) *Builder {
+	if _, ok := PaymentNetworkOf(id); !ok {
+		b.errs = append(b.errs, NewFieldError(id, merchantAccountInformationTagName, value, ReasonValue, fmt.Sprintf("mpm: %s is not an ID reserved for payment networks", id)))
+		return b
+	}
+	t, err := tlv.New(id, value)
+	if err != nil {
+		b.errs = append(b.errs, NewFieldError(id, merchantAccountInformationTagName, value, ReasonLength, fmt.Sprintf("mpm: %s", err)))
+		return b
+	}
+	b.c.MerchantAccountInformation = append(b.c.MerchantAccountInformation, t)
+	return b
+}
+
+// MerchantAccount adds a Merchant Account Information template of guid and data to the first free ID in 26–51.
+// Length of each data is computed and need not be set.
+func (b *Builder) MerchantAccount(guid string, data ...tlv.TLV) *Builder {
+	id := merchantAccountInformationTemplateIDFrom + b.merchantAccountInformationTemplates
+	if merchantAccountInformationIDTo < id {
+		b.errs = append(b.errs, NewFieldError("", merchantAccountInformationTagName, guid, ReasonUnexpected, fmt.Sprintf("mpm: no free ID of MerchantAccountInformation for %s", guid)))
+		return b
+	}
+	t, err := templateTLV(fmt.Sprintf("%02d", id), guid, data)
+	if err != nil {
+		b.errs = append(b.errs, err)
+		return b
+	}
+	b.merchantAccountInformationTemplates++
+	b.c.MerchantAccountInformation = append(b.c.MerchantAccountInformation, t)
+	return b
+}
+
+// MerchantCategoryCode sets Merchant Category Code.
+func (b *Builder) MerchantCategoryCode(mcc string) *Builder {
+	b.c.MerchantCategoryCode = mcc
+	return b
+}
+
+// TransactionCurrency sets Transaction Currency as ISO 4217 numeric code.
+func (b *Builder) TransactionCurrency(currency string) *Builder {
+	b.c.TransactionCurrency = currency
+	return b
+}
+
+// TransactionAmount sets Transaction Amount.
+func (b *Builder) TransactionAmount(amount string) *Builder {
+	b.c.TransactionAmount = NullString{String: amount, Valid: true}
+	return b
+}
+
+// Tip makes the mobile application prompt the consumer to enter a tip.
+func (b *Builder) Tip() *Builder {
+	b.c.TipOrConvenienceIndicator = TipOrConvenienceIndicatorPrompt
+	b.c.ValueOfConvenienceFeeFixed = NullString{}
+	b.c.ValueOfConvenienceFeePercentage = NullString{}
+	return b
+}
+
+// ConvenienceFeeFixed sets a fixed convenience fee.
+func (b *Builder) ConvenienceFeeFixed(fee string) *Builder {
+	b.c.TipOrConvenienceIndicator = TipOrConvenienceIndicatorFixed
+	b.c.ValueOfConvenienceFeeFixed = NullString{String: fee, Valid: true}
+	b.c.ValueOfConvenienceFeePercentage = NullString{}
+	return b
+}
+
+// ConvenienceFeePercentage sets a percentage convenience fee.
+func (b *Builder) ConvenienceFeePercentage(percentage string) *Builder {
+	b.c.TipOrConvenienceIndicator = TipOrConvenienceIndicatorPercentage
+	b.c.ValueOfConvenienceFeeFixed = NullString{}
+	b.c.ValueOfConvenienceFeePercentage = NullString{String: percentage, Valid: true}
+	return b
+}
+
+// CountryCode sets Country Code as ISO 3166-1 alpha 2 code.
+func (b *Builder) CountryCode(country string) *Builder {
+	b.c.CountryCode = country
+	return b
+}
+
+// MerchantName sets Merchant Name.
+func (b *Builder) MerchantName(name string) *Builder {
+	b.c.MerchantName = name
+	return b
+}
+
+// MerchantCity sets Merchant City.
+func (b *Builder) MerchantCity(city string) *Builder {
+	b.c.MerchantCity = city
+	return b
+}
+
+// PostalCode sets Postal Code.
+func (b *Builder) PostalCode(postalCode string) *Builder {
+	b.c.PostalCode = postalCode
+	return b
+}
+
+// AdditionalData sets Additional Data Field Template.
+func (b *Builder) AdditionalData(a AdditionalDataFieldTemplate) *Builder {
+	b.c.AdditionalDataFieldTemplate = a
+	return b
+}
+
+// MerchantInformation adds Merchant Information—Language Template.
+// The first call sets Code.MerchantInformation and the following ones add Code.AlternateMerchantInformation.
+func (b *Builder) MerchantInformation(languagePreference, name, city string) *Builder {
+	m := NullMerchantInformation{
+		LanguagePreference: languagePreference,
+		Name:               name,
+		City:               city,
+		Valid:              true,
+	}
+	if !b.c.MerchantInformation.Valid {
+		b.c.MerchantInformation = m
+		return b
+	}
+	b.c.AlternateMerchantInformation = append(b.c.AlternateMerchantInformation, m)
+	return b
+}
+
+// UnreservedTemplate adds an Unreserved Template of guid and data to the first free ID in 80–99.
+// Length of each data is computed and need not be set.
+func (b *Builder) UnreservedTemplate(guid string, data ...tlv.TLV) *Builder {
+	id := unreservedTemplatesIDFrom + b.unreservedTemplates
+	if unreservedTemplatesIDTo < id {
+		b.errs = append(b.errs, NewFieldError("", unreservedTemplatesTagName, guid, ReasonUnexpected, fmt.Sprintf("mpm: no free ID of UnreservedTemplates for %s", guid)))
+		return b
+	}
+	t, err := templateTLV(fmt.Sprintf("%02d", id), guid, data)
+	if err != nil {
+		b.errs = append(b.errs, err)
+		return b
+	}
+	b.unreservedTemplates++
+	b.c.UnreservedTemplates = append(b.c.UnreservedTemplates, t)
+	return b
+}
+
+// Build validates the Code with ConformanceValidators and vfs, and returns it.
+// It returns the first problem of the calls or the validators.
+func (b *Builder) Build(vfs ...ValidatorFunc) (*Code, error) {
+	if len(b.errs) != 0 {
+		return nil, b.errs[0]
+	}
+	c := b.c
+	c.MerchantAccountInformation = append([]tlv.TLV(nil), b.c.MerchantAccountInformation...)
+	c.AlternateMerchantInformation = append(AlternateMerchantInformation(nil), b.c.AlternateMerchantInformation...)
+	c.UnreservedTemplates = append([]tlv.TLV(nil), b.c.UnreservedTemplates...)
+
+	vfs = append(vfs, ConformanceValidators()...)
+	if errs := validate(&c, vfs, false); len(errs) != 0 {
+		return nil, errs[0]
+	}
+	return &c, nil
+}
+
+func templateTLV(id, guid string, data []tlv.TLV) (tlv.TLV, error) {
+	m := MerchantAccountInformationTemplate{
+		ID:                       id,
+		GloballyUniqueIdentifier: guid,
+		Data:                     make([]tlv.TLV, 0, len(data)),
+	}
+	for _, d := range data {
+		m.Data = append(m.Data, tlv.TLV{Tag: d.Tag, Value: d.Value}) // Length is computed by Tokenize
+	}
+	return m.TLV()
+}
@@ -0,0 +1,158 @@
+package mpm_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+func TestBuilder_Build(t *testing.T) {
+	tests := []struct {
+		name       string
+		give       *mpm.Builder
+		want       *mpm.Code
+		wantErr    bool
+		wantID     string
+		wantReason mpm.Reason
+	}{
+		{
+			name: "assigns IDs and lengths",
+			give: mpm.NewBuilder().
+				MerchantCategoryCode("4111").
+				TransactionCurrency("156").
+				CountryCode("CN").
+				MerchantName("BEST TRANSPORT").
+				MerchantCity("BEIJING").
+				PaymentNetworkAccount("02", "4000123456789012").
+				MerchantAccount("D15600000000", tlv.TLV{Tag: "05", Value: "A93FO3230Q"}).
+				MerchantAccount("D15600000001", tlv.TLV{Tag: "03", Length: "99", Value: "12345678"}).
+				ConvenienceFeeFixed("500").
+				MerchantInformation("ZH", "最佳运输", "北京").
+				UnreservedTemplate("A011223344998877", tlv.TLV{Tag: "07", Value: "12345678"}),
+			want: &mpm.Code{
+				PayloadFormatIndicator: "01",
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "02", Length: "16", Value: "4000123456789012"},
+					{Tag: "26", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+					{Tag: "27", Length: "28", Value: "0012D15600000001030812345678"},
+				},
+				MerchantCategoryCode:       "4111",
+				TransactionCurrency:        "156",
+				TipOrConvenienceIndicator:  mpm.TipOrConvenienceIndicatorFixed,
+				ValueOfConvenienceFeeFixed: mpm.NullString{String: "500", Valid: true},
+				CountryCode:                "CN",
+				MerchantName:               "BEST TRANSPORT",
+				MerchantCity:               "BEIJING",
+				MerchantInformation:        mpm.NullMerchantInformation{LanguagePreference: "ZH", Name: "最佳运输", City: "北京", Valid: true},
+				UnreservedTemplates: []tlv.TLV{
+					{Tag: "80", Length: "32", Value: "0016A011223344998877070812345678"},
+				},
+			},
+		},
+		{
+			name: "fail: not an ID of payment networks",
+			give: mpm.NewBuilder().
+				MerchantCategoryCode("4111").
+				TransactionCurrency("156").
+				CountryCode("CN").
+				MerchantName("BEST TRANSPORT").
+				MerchantCity("BEIJING").
+				PaymentNetworkAccount("26", "4000123456789012"),
+			wantErr:    true,
+			wantID:     "26",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "fail: account is too long",
+			give: mpm.NewBuilder().
+				MerchantCategoryCode("4111").
+				TransactionCurrency("156").
+				CountryCode("CN").
+				MerchantName("BEST TRANSPORT").
+				MerchantCity("BEIJING").
+				PaymentNetworkAccount("02", strings.Repeat("4", 100)),
+			wantErr:    true,
+			wantID:     "02",
+			wantReason: mpm.ReasonLength,
+		},
+		{
+			name: "fail: no free ID of templates",
+			give: func() *mpm.Builder {
+				b := mpm.NewBuilder().
+					MerchantCategoryCode("4111").
+					TransactionCurrency("156").
+					CountryCode("CN").
+					MerchantName("BEST TRANSPORT").
+					MerchantCity("BEIJING")
+				for i := 0; i < 27; i++ {
+					b = b.MerchantAccount("D15600000000")
+				}
+				return b
+			}(),
+			wantErr:    true,
+			wantID:     "",
+			wantReason: mpm.ReasonUnexpected,
+		},
+		{
+			name: "fail: no free ID of unreserved templates",
+			give: func() *mpm.Builder {
+				b := mpm.NewBuilder().
+					MerchantCategoryCode("4111").
+					TransactionCurrency("156").
+					CountryCode("CN").
+					MerchantName("BEST TRANSPORT").
+					MerchantCity("BEIJING").
+					MerchantAccount("D15600000000")
+				for i := 0; i < 21; i++ {
+					b = b.UnreservedTemplate("A011223344998877")
+				}
+				return b
+			}(),
+			wantErr:    true,
+			wantID:     "",
+			wantReason: mpm.ReasonUnexpected,
+		},
+		{
+			name: "fail: conformance",
+			give: mpm.NewBuilder().
+				MerchantCategoryCode("4111").
+				TransactionCurrency("156").
+				CountryCode("CHN").
+				MerchantName("BEST TRANSPORT").
+				MerchantCity("BEIJING").
+				MerchantAccount("D15600000000"),
+			wantErr:    true,
+			wantID:     "58",
+			wantReason: mpm.ReasonLength,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.Build()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Build() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, mpm.InvalidFormat) {
+					t.Errorf("Build() error = %v should be InvalidFormat", err)
+				}
+				var fe *mpm.FieldError
+				if !errors.As(err, &fe) || fe.ID != tt.wantID || fe.Reason != tt.wantReason {
+					t.Errorf("Build() error = %#v, want ID %s of %s", err, tt.wantID, tt.wantReason)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Build() = %+v, want %+v", got, tt.want)
+			}
+			if _, err := mpm.Encode(got); err != nil {
+				t.Errorf("Encode() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,446 @@
+package mpm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+// ConformanceValidators returns validators of the format (N, ans and S) and presence (M, C and O) rules
+// of every root data object defined in EMV QRCPS Merchant-Presented Mode.
+// Decode and Encode run them unless SkipConformance is set, counting lengths in their LengthUnit and FieldLengthUnits.
+// The returned validators count lengths in characters.
+func ConformanceValidators() []ValidatorFunc {
+	return conformanceValidators(func(string) tlv.LengthUnit { return tlv.LengthUnitRune })
+}
+
+// conformanceValidators returns ConformanceValidators which count lengths of the data objects of root IDs in unitOf.
+func conformanceValidators(unitOf func(id string) tlv.LengthUnit) []ValidatorFunc {
+	return []ValidatorFunc{
+		validatePayloadFormatIndicator,
+		validatePointOfInitiationMethod,
+		func(c *Code) error { return validateMerchantAccountInformation(c, unitOf) },
+		validateMerchantCategoryCode,
+		validateTransactionCurrency,
+		validateTransactionAmount,
+		validateTipOrConvenienceIndicator,
+		validateCountryCode,
+		func(c *Code) error { return validateMerchantName(c, unitOf("59")) },
+		func(c *Code) error { return validateMerchantCity(c, unitOf("60")) },
+		func(c *Code) error { return validatePostalCode(c, unitOf("61")) },
+		func(c *Code) error {
+			return validateAdditionalDataFieldTemplate(c, unitOf(additionalDataFieldTemplateID))
+		},
+		func(c *Code) error { return validateMerchantInformation(c, unitOf(merchantInformationID)) },
+		func(c *Code) error { return validateRFUForEMVCo(c, unitOf) },
+		func(c *Code) error { return validateUnreservedTemplates(c, unitOf) },
+		func(c *Code) error { return validateOthers(c, unitOf) },
+	}
+}
+
+const (
+	maxValueLength = 99
+
+	payloadFormatIndicatorValue = "01"
+
+	merchantCategoryCodeLength       = 4
+	transactionCurrencyLength        = 3
+	transactionAmountMaxLength       = 13
+	valueOfConvenienceFeeFixedMaxLen = 13
+	valueOfConvenienceFeePercentLen  = 5
+	countryCodeLength                = 2
+	postalCodeMaxLength              = 10
+	globallyUniqueIdentifierMaxLen   = 32
+)
+
+// IsNumeric reports whether s consists of ASCII digits only, the format N. It is true for an empty s.
+func IsNumeric(s string) bool {
+	for _, r := range s {
+		if r < '0' || '9' < r {
+			return false
+		}
+	}
+	return true
+}
+
+// isAlphanumericSpecial reports whether s consists of printable ASCII characters only, the format ans.
+func isAlphanumericSpecial(s string) bool {
+	for _, r := range s {
+		if r < 0x20 || 0x7e < r {
+			return false
+		}
+	}
+	return true
+}
+
+func isUpperAlpha(s string) bool {
+	for _, r := range s {
+		if r < 'A' || 'Z' < r {
+			return false
+		}
+	}
+	return true
+}
+
+// isDecimal reports whether s is a decimal number without sign and thousands separators, e.g. "98.73".
+func isDecimal(s string) bool {
+	if s == "" || s == "." || 1 < strings.Count(s, ".") {
+		return false
+	}
+	return IsNumeric(strings.Replace(s, ".", "", 1))
+}
+
+// validateDecimals checks the amount v has no more decimals than the minor unit of Transaction Currency.
+// Amounts of unknown currencies are not checked.
+func validateDecimals(c *Code, v string) error {
+	exponent, ok := CurrencyExponent(c.TransactionCurrency)
+	if !ok {
+		return nil
+	}
+	_, err := ParseAmount(v, exponent)
+	return err
+}
+
+// validateNumeric checks v is present and a N of fixed length.
+func validateNumeric(id, field, v string, length int) error {
+	if len(v) != length {
+		return NewFieldError(id, field, v, lengthReason(v), fmt.Sprintf("mpm: length of %s should be %d", field, length))
+	}
+	if !IsNumeric(v) {
+		return NewFieldError(id, field, v, ReasonValue, fmt.Sprintf("mpm: %s should be numeric", field))
+	}
+	return nil
+}
+
+// validateAlphanumericSpecial checks v is a ans at most max in unit.
+func validateAlphanumericSpecial(id, field, v string, max int, unit tlv.LengthUnit) error {
+	if max < unit.Len(v) {
+		return NewFieldError(id, field, v, ReasonLength, fmt.Sprintf("mpm: length of %s should be less than %d", field, max))
+	}
+	if !isAlphanumericSpecial(v) {
+		return NewFieldError(id, field, v, ReasonValue, fmt.Sprintf("mpm: %s should consist of alphanumeric and special characters", field))
+	}
+	return nil
+}
+
+func validatePayloadFormatIndicator(c *Code) error {
+	if c.PayloadFormatIndicator != "" && c.PayloadFormatIndicator != payloadFormatIndicatorValue {
+		return NewFieldError(payloadFormatIndicatorID, "PayloadFormatIndicator", c.PayloadFormatIndicator, ReasonValue, fmt.Sprintf("mpm: PayloadFormatIndicator should be %s", payloadFormatIndicatorValue))
+	}
+	return nil
+}
+
+func validatePointOfInitiationMethod(c *Code) error {
+	switch c.PointOfInitiationMethod {
+	case "", PointOfInitiationMethodStatic, PointOfInitiationMethodDynamic:
+		return nil
+	}
+	return NewFieldError("01", "PointOfInitiationMethod", string(c.PointOfInitiationMethod), ReasonValue, fmt.Sprintf("mpm: PointOfInitiationMethod should be %s or %s", PointOfInitiationMethodStatic, PointOfInitiationMethodDynamic))
+}
+
+func validateMerchantAccountInformation(c *Code, unitOf func(id string) tlv.LengthUnit) error {
+	if len(c.MerchantAccountInformation) == 0 {
+		return NewFieldError("", "MerchantAccountInformation", "", ReasonMissing, "mpm: at least one MerchantAccountInformation should be represented")
+	}
+	var errs Errors
+	for i, t := range c.MerchantAccountInformation {
+		field := fmt.Sprintf("MerchantAccountInformation[%d]", i)
+		id, err := strconv.Atoi(t.Tag)
+		if err != nil || len(t.Tag) != tagLength || id < merchantAccountInformationIDFrom || merchantAccountInformationIDTo < id {
+			errs = append(errs, NewFieldError(t.Tag, field, t.Value, ReasonValue, fmt.Sprintf("mpm: tag of %s should be between %02d and %02d", field, merchantAccountInformationIDFrom, merchantAccountInformationIDTo)))
+			continue
+		}
+		if t.Value == "" || maxValueLength < unitOf(t.Tag).Len(t.Value) {
+			errs = append(errs, NewFieldError(t.Tag, field, t.Value, lengthReason(t.Value), fmt.Sprintf("mpm: length of %s should be between 1 and %d", field, maxValueLength)))
+			continue
+		}
+		if !isAlphanumericSpecial(t.Value) {
+			errs = append(errs, NewFieldError(t.Tag, field, t.Value, ReasonValue, fmt.Sprintf("mpm: %s should consist of alphanumeric and special characters", field)))
+			continue
+		}
+		if id < merchantAccountInformationTemplateIDFrom {
+			continue // primitive data objects reserved for payment networks
+		}
+		v := MerchantAccountInformationTemplate{ID: t.Tag}
+		if err := v.ScanUnit([]rune(t.Value), unitOf(t.Tag)); err != nil {
+			errs = append(errs, NewFieldError(t.Tag, field, t.Value, ReasonValue, fmt.Sprintf("mpm: %s", err)))
+			continue
+		}
+		if v.GloballyUniqueIdentifier == "" || globallyUniqueIdentifierMaxLen < len(v.GloballyUniqueIdentifier) {
+			errs = append(errs, NewFieldError(t.Tag+".00", field+".GloballyUniqueIdentifier", v.GloballyUniqueIdentifier, lengthReason(v.GloballyUniqueIdentifier), fmt.Sprintf("mpm: length of tag 00 of %s should be between 1 and %d", field, globallyUniqueIdentifierMaxLen)))
+		}
+	}
+	return errs.err()
+}
+
+func validateMerchantCategoryCode(c *Code) error {
+	return validateNumeric("52", "MerchantCategoryCode", c.MerchantCategoryCode, merchantCategoryCodeLength)
+}
+
+func validateTransactionCurrency(c *Code) error {
+	return validateNumeric("53", "TransactionCurrency", c.TransactionCurrency, transactionCurrencyLength)
+}
+
+func validateTransactionAmount(c *Code) error {
+	if !c.TransactionAmount.Valid {
+		return nil
+	}
+	v := c.TransactionAmount.String
+	if v == "" || transactionAmountMaxLength < len(v) {
+		return NewFieldError("54", "TransactionAmount", v, lengthReason(v), fmt.Sprintf("mpm: length of TransactionAmount should be between 1 and %d", transactionAmountMaxLength))
+	}
+	if !isDecimal(v) {
+		return NewFieldError("54", "TransactionAmount", v, ReasonValue, "mpm: TransactionAmount should be a decimal number")
+	}
+	if err := validateDecimals(c, v); err != nil {
+		return NewFieldError("54", "TransactionAmount", v, ReasonValue, err.Error())
+	}
+	return nil
+}
+
+func validateTipOrConvenienceIndicator(c *Code) error {
+	var errs Errors
+	switch c.TipOrConvenienceIndicator {
+	case "", TipOrConvenienceIndicatorPrompt, TipOrConvenienceIndicatorFixed, TipOrConvenienceIndicatorPercentage:
+	default:
+		errs = append(errs, NewFieldError("55", "TipOrConvenienceIndicator", string(c.TipOrConvenienceIndicator), ReasonValue, "mpm: TipOrConvenienceIndicator should be one of 01, 02 and 03"))
+	}
+
+	fixed := c.ValueOfConvenienceFeeFixed
+	switch {
+	case c.TipOrConvenienceIndicator == TipOrConvenienceIndicatorFixed && !fixed.Valid:
+		errs = append(errs, NewFieldError("56", "ValueOfConvenienceFeeFixed", "", ReasonMissing, fmt.Sprintf("mpm: ValueOfConvenienceFeeFixed should be represented if TipOrConvenienceIndicator is %s", TipOrConvenienceIndicatorFixed)))
+	case c.TipOrConvenienceIndicator != TipOrConvenienceIndicatorFixed && fixed.Valid:
+		errs = append(errs, NewFieldError("56", "ValueOfConvenienceFeeFixed", fixed.String, ReasonUnexpected, fmt.Sprintf("mpm: ValueOfConvenienceFeeFixed should be represented only if TipOrConvenienceIndicator is %s", TipOrConvenienceIndicatorFixed)))
+	case fixed.Valid && (fixed.String == "" || valueOfConvenienceFeeFixedMaxLen < len(fixed.String)):
+		errs = append(errs, NewFieldError("56", "ValueOfConvenienceFeeFixed", fixed.String, lengthReason(fixed.String), fmt.Sprintf("mpm: length of ValueOfConvenienceFeeFixed should be between 1 and %d", valueOfConvenienceFeeFixedMaxLen)))
+	case fixed.Valid && !isDecimal(fixed.String):
+		errs = append(errs, NewFieldError("56", "ValueOfConvenienceFeeFixed", fixed.String, ReasonValue, "mpm: ValueOfConvenienceFeeFixed should be a decimal number"))
+	case fixed.Valid:
+		if err := validateDecimals(c, fixed.String); err != nil {
+			errs = append(errs, NewFieldError("56", "ValueOfConvenienceFeeFixed", fixed.String, ReasonValue, err.Error()))
+		}
+	}
+
+	percentage := c.ValueOfConvenienceFeePercentage
+	switch {
+	case c.TipOrConvenienceIndicator == TipOrConvenienceIndicatorPercentage && !percentage.Valid:
+		errs = append(errs, NewFieldError("57", "ValueOfConvenienceFeePercentage", "", ReasonMissing, fmt.Sprintf("mpm: ValueOfConvenienceFeePercentage should be represented if TipOrConvenienceIndicator is %s", TipOrConvenienceIndicatorPercentage)))
+	case c.TipOrConvenienceIndicator != TipOrConvenienceIndicatorPercentage && percentage.Valid:
+		errs = append(errs, NewFieldError("57", "ValueOfConvenienceFeePercentage", percentage.String, ReasonUnexpected, fmt.Sprintf("mpm: ValueOfConvenienceFeePercentage should be represented only if TipOrConvenienceIndicator is %s", TipOrConvenienceIndicatorPercentage)))
+	case percentage.Valid && (percentage.String == "" || valueOfConvenienceFeePercentLen < len(percentage.String)):
+		errs = append(errs, NewFieldError("57", "ValueOfConvenienceFeePercentage", percentage.String, lengthReason(percentage.String), fmt.Sprintf("mpm: length of ValueOfConvenienceFeePercentage should be between 1 and %d", valueOfConvenienceFeePercentLen)))
+	case percentage.Valid:
+		if f, err := strconv.ParseFloat(percentage.String, 64); !isDecimal(percentage.String) || err != nil || f <= 0 || 100 <= f {
+			errs = append(errs, NewFieldError("57", "ValueOfConvenienceFeePercentage", percentage.String, ReasonValue, "mpm: ValueOfConvenienceFeePercentage should be a decimal number between 00.01 and 99.99"))
+		}
+	}
+	return errs.err()
+}
+
+func validateCountryCode(c *Code) error {
+	if len(c.CountryCode) != countryCodeLength {
+		return NewFieldError("58", "CountryCode", c.CountryCode, lengthReason(c.CountryCode), fmt.Sprintf("mpm: length of CountryCode should be %d", countryCodeLength))
+	}
+	if !isUpperAlpha(c.CountryCode) {
+		return NewFieldError("58", "CountryCode", c.CountryCode, ReasonValue, "mpm: CountryCode should be ISO 3166-1 alpha-2 code")
+	}
+	return nil
+}
+
+func validatePostalCode(c *Code, unit tlv.LengthUnit) error {
+	return validateAlphanumericSpecial("61", "PostalCode", c.PostalCode, postalCodeMaxLength, unit)
+}
+
+func lengthReason(v string) Reason {
+	if v == "" {
+		return ReasonMissing
+	}
+	return ReasonLength
+}
+
+func validateMerchantName(c *Code, unit tlv.LengthUnit) error {
+	if c.MerchantName == "" || 25 < unit.Len(c.MerchantName) {
+		return NewFieldError("59", "MerchantName", c.MerchantName, lengthReason(c.MerchantName), "mpm: length of MerchantName should be between 1 and 25")
+	}
+	return validateAlphanumericSpecial("59", "MerchantName", c.MerchantName, 25, unit)
+}
+
+func validateMerchantCity(c *Code, unit tlv.LengthUnit) error {
+	if c.MerchantCity == "" || 15 < unit.Len(c.MerchantCity) {
+		return NewFieldError("60", "MerchantCity", c.MerchantCity, lengthReason(c.MerchantCity), "mpm: length of MerchantCity should be between 1 and 15")
+	}
+	return validateAlphanumericSpecial("60", "MerchantCity", c.MerchantCity, 15, unit)
+}
+
+const (
+	additionalDataFieldMaxLength           = 25
+	additionalConsumerDataRequestMaxLength = 3
+)
+
+func validateAdditionalDataFieldTemplate(c *Code, unit tlv.LengthUnit) error {
+	var errs Errors
+	a := c.AdditionalDataFieldTemplate
+	for _, f := range []struct {
+		id    string
+		name  string
+		value string
+	}{
+		{"01", "BillNumber", a.BillNumber},
+		{"02", "MobileNumber", a.MobileNumber},
+		{"03", "StoreLabel", a.StoreLabel},
+		{"04", "LoyaltyNumber", a.LoyaltyNumber},
+		{"05", "ReferenceLabel", a.ReferenceLabel},
+		{"06", "CustomerLabel", a.CustomerLabel},
+		{"07", "TerminalLabel", a.TerminalLabel},
+		{"08", "PurposeOfTransaction", a.PurposeOfTransaction},
+	} {
+		if err := validateAlphanumericSpecial("62."+f.id, "AdditionalDataFieldTemplate."+f.name, f.value, additionalDataFieldMaxLength, unit); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := validateAdditionalConsumerDataRequest(a.AdditionalConsumerDataRequest); err != nil {
+		errs = append(errs, err)
+	}
+	if v, err := a.TokenizeUnit(unit); err == nil && maxValueLength < unit.Len(v) {
+		errs = append(errs, NewFieldError("62", "AdditionalDataFieldTemplate", v, ReasonLength, fmt.Sprintf("mpm: length of AdditionalDataFieldTemplate should be less than %d", maxValueLength)))
+	}
+	return errs.err()
+}
+
+func validateAdditionalConsumerDataRequest(v string) error {
+	const field = "AdditionalDataFieldTemplate.AdditionalConsumerDataRequest"
+	if additionalConsumerDataRequestMaxLength < utf8.RuneCountInString(v) {
+		return NewFieldError("62.09", field, v, ReasonLength, fmt.Sprintf("mpm: length of %s should be less than %d", field, additionalConsumerDataRequestMaxLength))
+	}
+	for i, r := range v {
+		// "A" for address, "M" for mobile number and "E" for email address, each at most once.
+		if !strings.ContainsRune("AME", r) || strings.ContainsRune(v[:i], r) {
+			return NewFieldError("62.09", field, v, ReasonValue, fmt.Sprintf("mpm: %s should be a combination of A, M and E", field))
+		}
+	}
+	return nil
+}
+
+func validateMerchantInformation(c *Code, unit tlv.LengthUnit) error {
+	var errs Errors
+	languages := make(map[string]struct{})
+	if c.MerchantInformation.Valid {
+		errs = append(errs, validateLanguageTemplate(c.MerchantInformation, "MerchantInformation", languages, unit)...)
+	}
+	for i, m := range c.AlternateMerchantInformation {
+		field := fmt.Sprintf("AlternateMerchantInformation[%d]", i)
+		if !c.MerchantInformation.Valid {
+			errs = append(errs, NewFieldError("64", field, "", ReasonUnexpected, fmt.Sprintf("mpm: %s should follow MerchantInformation", field)))
+			break
+		}
+		if !m.Valid {
+			errs = append(errs, NewFieldError("64", field, "", ReasonMissing, fmt.Sprintf("mpm: %s should have LanguagePreference and Name", field)))
+			continue
+		}
+		errs = append(errs, validateLanguageTemplate(m, field, languages, unit)...)
+	}
+	return errs.err()
+}
+
+// validateLanguageTemplate validates a Merchant Information—Language Template.
+// languages holds the language codes of the preceding templates. Lengths are counted in unit.
+func validateLanguageTemplate(m NullMerchantInformation, field string, languages map[string]struct{}, unit tlv.LengthUnit) Errors {
+	var errs Errors
+	if len(m.LanguagePreference) != 2 {
+		errs = append(errs, NewFieldError("64.00", field+".LanguagePreference", m.LanguagePreference, lengthReason(m.LanguagePreference), fmt.Sprintf("mpm: length of %s.LanguagePreference should be 2", field)))
+	} else if !IsLanguageCode(m.LanguagePreference) {
+		errs = append(errs, NewFieldError("64.00", field+".LanguagePreference", m.LanguagePreference, ReasonValue, fmt.Sprintf("mpm: %s.LanguagePreference should be an ISO 639-1 code", field)))
+	} else if _, ok := languages[strings.ToLower(m.LanguagePreference)]; ok {
+		errs = append(errs, NewFieldError("64.00", field+".LanguagePreference", m.LanguagePreference, ReasonUnexpected, fmt.Sprintf("mpm: %s.LanguagePreference %s is duplicated", field, m.LanguagePreference)))
+	}
+	languages[strings.ToLower(m.LanguagePreference)] = struct{}{}
+	if m.Name == "" || 25 < unit.Len(m.Name) {
+		errs = append(errs, NewFieldError("64.01", field+".Name", m.Name, lengthReason(m.Name), fmt.Sprintf("mpm: length of %s.Name should be between 1 and 25", field)))
+	}
+	if 15 < unit.Len(m.City) {
+		errs = append(errs, NewFieldError("64.02", field+".City", m.City, ReasonLength, fmt.Sprintf("mpm: length of %s.City should be less than 15", field)))
+	}
+	if v, err := m.TokenizeUnit(unit); err == nil && maxValueLength < unit.Len(v) {
+		errs = append(errs, NewFieldError("64", field, v, ReasonLength, fmt.Sprintf("mpm: length of %s should be less than %d", field, maxValueLength)))
+	}
+	return errs
+}
+
+func validateRFUForEMVCo(c *Code, unitOf func(id string) tlv.LengthUnit) error {
+	var errs Errors
+	for i, t := range c.RFUForEMVCo {
+		field := fmt.Sprintf("RFUForEMVCo[%d]", i)
+		id, err := strconv.Atoi(t.Tag)
+		if err != nil || len(t.Tag) != tagLength || id < rfuForEMVCoIDFrom || rfuForEMVCoIDTo < id {
+			errs = append(errs, NewFieldError(t.Tag, field, t.Value, ReasonValue, fmt.Sprintf("mpm: tag of %s should be between %02d and %02d", field, rfuForEMVCoIDFrom, rfuForEMVCoIDTo)))
+			continue
+		}
+		if t.Value == "" || maxValueLength < unitOf(t.Tag).Len(t.Value) {
+			errs = append(errs, NewFieldError(t.Tag, field, t.Value, lengthReason(t.Value), fmt.Sprintf("mpm: length of %s should be between 1 and %d", field, maxValueLength)))
+		}
+	}
+	return errs.err()
+}
+
+func validateUnreservedTemplates(c *Code, unitOf func(id string) tlv.LengthUnit) error {
+	if len(c.UnreservedTemplates) == 0 {
+		return nil
+	}
+	var errs Errors
+	for i, t := range c.UnreservedTemplates {
+		field := fmt.Sprintf("UnreservedTemplates[%d]", i)
+		unit := unitOf(t.Tag)
+		if maxValueLength < unit.Len(t.Value) {
+			errs = append(errs, NewFieldError(t.Tag, field, t.Value, ReasonLength, fmt.Sprintf("mpm: length of %s should be less than %d", field, maxValueLength)))
+			continue
+		}
+		var v struct {
+			GloballyUniqueIdentifier string `emv:"00"`
+		}
+		dec := tlv.NewDecoder(strings.NewReader(t.Value), tagName, MaxSize, tagLength, lenLength, nil)
+		dec.LengthUnit(unit)
+		if err := dec.Decode(&v); err != nil {
+			switch e := err.(type) {
+			case *tlv.MalformedPayloadError:
+				errs = append(errs, NewFieldError(t.Tag, field, t.Value, ReasonValue, fmt.Sprintf("mpm: %s", e.Error())))
+				continue
+			}
+			errs = append(errs, err)
+			continue
+		}
+		if v.GloballyUniqueIdentifier == "" || globallyUniqueIdentifierMaxLen < len(v.GloballyUniqueIdentifier) {
+			errs = append(errs, NewFieldError(t.Tag+".00", field+".GloballyUniqueIdentifier", v.GloballyUniqueIdentifier, lengthReason(v.GloballyUniqueIdentifier), fmt.Sprintf("mpm: length of tag 00 of %s should be between 1 and %d", field, globallyUniqueIdentifierMaxLen)))
+		}
+	}
+	return errs.err()
+}
+
+// validateOthers checks the data objects which Encode writes as is.
+// Every numeric ID belongs to a field of Code or CRC, so Others should hold the other IDs only.
+func validateOthers(c *Code, unitOf func(id string) tlv.LengthUnit) error {
+	var errs Errors
+	for i, t := range c.Others {
+		field := fmt.Sprintf("Others[%d]", i)
+		if len(t.Tag) != tagLength {
+			errs = append(errs, NewFieldError(t.Tag, field, t.Value, ReasonValue, fmt.Sprintf("mpm: tag of %s should be %d characters, got %q", field, tagLength, t.Tag)))
+			continue
+		}
+		if IsNumeric(t.Tag) {
+			errs = append(errs, NewFieldError(t.Tag, field, t.Value, ReasonUnexpected, fmt.Sprintf("mpm: %s should not hold %s of a field of Code or CRC", field, t.Tag)))
+			continue
+		}
+		n := unitOf(t.Tag).Len(t.Value)
+		if t.Value == "" || maxValueLength < n {
+			errs = append(errs, NewFieldError(t.Tag, field, t.Value, lengthReason(t.Value), fmt.Sprintf("mpm: length of %s should be between 1 and %d", field, maxValueLength)))
+			continue
+		}
+		if l := fmt.Sprintf("%02d", n); t.Length != "" && t.Length != l {
+			errs = append(errs, NewFieldError(t.Tag, field, t.Value, ReasonLength, fmt.Sprintf("mpm: Length of %s should be %s, got %s", field, l, t.Length)))
+		}
+	}
+	return errs.err()
+}
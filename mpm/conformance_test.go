@@ -0,0 +1,1440 @@
+package mpm_test
+
+import (
+	"errors"
+	"testing"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+func TestConformanceValidators(t *testing.T) {
+	tests := []struct {
+		name       string
+		give       *mpm.Code
+		wantErr    bool
+		wantID     string
+		wantReason mpm.Reason
+
+		// wantSkipConformanceErr is set if the data object cannot be encoded even without conformance
+		wantSkipConformanceErr bool
+	}{
+		{
+			name: "pass",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+		},
+		{
+			name: "err: PayloadFormatIndicator is not 01",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "02",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "00",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: PointOfInitiationMethod is unknown",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: "13",
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "01",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: MerchantAccountInformation is missing",
+			give: &mpm.Code{
+				PayloadFormatIndicator:          "01",
+				PointOfInitiationMethod:         mpm.PointOfInitiationMethodDynamic,
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "",
+			wantReason: mpm.ReasonMissing,
+		},
+		{
+			name: "err: MerchantAccountInformation has a tag out of range",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "52", Length: "04", Value: "0000"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "52",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: MerchantAccountInformation template is malformed",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "06", Value: "001212"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "26",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: MerchantAccountInformation template lacks GloballyUniqueIdentifier",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "08", Value: "01041234"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "26.00",
+			wantReason: mpm.ReasonMissing,
+		},
+		{
+			name: "err: MerchantCategoryCode is not numeric",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "41A1",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "52",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: MerchantCategoryCode is missing",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "52",
+			wantReason: mpm.ReasonMissing,
+		},
+		{
+			name: "err: TransactionCurrency is too long",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "1560",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "53",
+			wantReason: mpm.ReasonLength,
+		},
+		{
+			name: "err: TransactionAmount has thousands separator",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "1,000.00", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "54",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: TransactionAmount is too long",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "12345678901234", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "54",
+			wantReason: mpm.ReasonLength,
+		},
+		{
+			name: "err: TransactionAmount has more decimals than TransactionCurrency",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "392",
+				TransactionAmount:               mpm.NullString{String: "100.5", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "54",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "pass: TransactionAmount of unknown TransactionCurrency",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "999",
+				TransactionAmount:               mpm.NullString{String: "100.555", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+		},
+		{
+			name: "err: TipOrConvenienceIndicator is unknown",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:      "4111",
+				TransactionCurrency:       "156",
+				TransactionAmount:         mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator: "04",
+				CountryCode:               "CN",
+				MerchantName:              "BEST TRANSPORT",
+				MerchantCity:              "BEIJING",
+				PostalCode:                "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "55",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: ValueOfConvenienceFeePercentage is missing",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:      "4111",
+				TransactionCurrency:       "156",
+				TransactionAmount:         mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator: mpm.TipOrConvenienceIndicatorPercentage,
+				CountryCode:               "CN",
+				MerchantName:              "BEST TRANSPORT",
+				MerchantCity:              "BEIJING",
+				PostalCode:                "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "57",
+			wantReason: mpm.ReasonMissing,
+		},
+		{
+			name: "err: ValueOfConvenienceFeePercentage is out of range",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "100", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "57",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: ValueOfConvenienceFeeFixed is unexpected",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeeFixed:      mpm.NullString{String: "1.00", Valid: true},
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "56",
+			wantReason: mpm.ReasonUnexpected,
+		},
+		{
+			name: "err: ValueOfConvenienceFeeFixed is missing",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:      "4111",
+				TransactionCurrency:       "156",
+				TransactionAmount:         mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator: mpm.TipOrConvenienceIndicatorFixed,
+				CountryCode:               "CN",
+				MerchantName:              "BEST TRANSPORT",
+				MerchantCity:              "BEIJING",
+				PostalCode:                "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "56",
+			wantReason: mpm.ReasonMissing,
+		},
+		{
+			name: "err: CountryCode is not alpha",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "C1",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "58",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: CountryCode is alpha-3",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CHN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "58",
+			wantReason: mpm.ReasonLength,
+		},
+		{
+			name: "err: MerchantName has non ans characters",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "最佳运输",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "59",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: MerchantName is missing",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "59",
+			wantReason: mpm.ReasonMissing,
+		},
+		{
+			name: "err: MerchantCity is too long",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING CITY CENTRE",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "60",
+			wantReason: mpm.ReasonLength,
+		},
+		{
+			name: "err: PostalCode is too long",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "12345678901",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "61",
+			wantReason: mpm.ReasonLength,
+		},
+		{
+			name: "err: BillNumber is too long",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					BillNumber: "12345678901234567890123456",
+				},
+			},
+			wantErr:    true,
+			wantID:     "62.01",
+			wantReason: mpm.ReasonLength,
+		},
+		{
+			name: "err: AdditionalDataFieldTemplate is too long",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					BillNumber:    "1234567890123456789012345",
+					MobileNumber:  "1234567890123456789012345",
+					StoreLabel:    "1234567890123456789012345",
+					LoyaltyNumber: "1234567890123456789012345",
+				},
+			},
+			wantErr:                true,
+			wantID:                 "62",
+			wantReason:             mpm.ReasonLength,
+			wantSkipConformanceErr: true,
+		},
+		{
+			name: "err: AdditionalConsumerDataRequest has unknown character",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "MX",
+				},
+			},
+			wantErr:    true,
+			wantID:     "62.09",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: AdditionalConsumerDataRequest has duplicated character",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "MM",
+				},
+			},
+			wantErr:    true,
+			wantID:     "62.09",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: AdditionalConsumerDataRequest is too long",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "AMEA",
+				},
+			},
+			wantErr:    true,
+			wantID:     "62.09",
+			wantReason: mpm.ReasonLength,
+		},
+		{
+			name: "pass: alternate languages",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+				MerchantInformation: mpm.NullMerchantInformation{LanguagePreference: "ZH", Name: "最佳运输", City: "北京", Valid: true},
+				AlternateMerchantInformation: mpm.AlternateMerchantInformation{
+					mpm.NullMerchantInformation{LanguagePreference: "ja", Name: "ベスト運輸", City: "北京", Valid: true},
+				},
+			},
+		},
+		{
+			name: "err: LanguagePreference is missing",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+				MerchantInformation: mpm.NullMerchantInformation{Name: "最佳运输", Valid: true},
+			},
+			wantErr:    true,
+			wantID:     "64.00",
+			wantReason: mpm.ReasonMissing,
+		},
+		{
+			name: "err: LanguagePreference is not an ISO 639-1 code",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+				MerchantInformation: mpm.NullMerchantInformation{LanguagePreference: "XX", Name: "最佳运输", Valid: true},
+			},
+			wantErr:    true,
+			wantID:     "64.00",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: LanguagePreference is duplicated",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+				MerchantInformation: mpm.NullMerchantInformation{LanguagePreference: "ZH", Name: "最佳运输", Valid: true},
+				AlternateMerchantInformation: mpm.AlternateMerchantInformation{
+					mpm.NullMerchantInformation{LanguagePreference: "zh", Name: "最佳运输", Valid: true},
+				},
+			},
+			wantErr:    true,
+			wantID:     "64.00",
+			wantReason: mpm.ReasonUnexpected,
+		},
+		{
+			name: "err: alternate Name is too long",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+				MerchantInformation: mpm.NullMerchantInformation{LanguagePreference: "ZH", Name: "最佳运输", Valid: true},
+				AlternateMerchantInformation: mpm.AlternateMerchantInformation{
+					mpm.NullMerchantInformation{LanguagePreference: "ja", Name: "ベスト運輸ベスト運輸ベスト運輸ベスト運輸ベスト運輸ベ", Valid: true},
+				},
+			},
+			wantErr:    true,
+			wantID:     "64.01",
+			wantReason: mpm.ReasonLength,
+		},
+		{
+			name: "err: City is too long",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+				MerchantInformation: mpm.NullMerchantInformation{LanguagePreference: "ZH", Name: "最佳运输", City: "北京北京北京北京北京北京北京北京", Valid: true},
+			},
+			wantErr:    true,
+			wantID:     "64.02",
+			wantReason: mpm.ReasonLength,
+		},
+		{
+			name: "err: AlternateMerchantInformation without MerchantInformation",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+				AlternateMerchantInformation: mpm.AlternateMerchantInformation{
+					mpm.NullMerchantInformation{LanguagePreference: "ZH", Name: "最佳运输", Valid: true},
+				},
+			},
+			wantErr:    true,
+			wantID:     "64",
+			wantReason: mpm.ReasonUnexpected,
+		},
+		{
+			name: "err: AlternateMerchantInformation lacks Name",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+				MerchantInformation: mpm.NullMerchantInformation{LanguagePreference: "ZH", Name: "最佳运输", Valid: true},
+				AlternateMerchantInformation: mpm.AlternateMerchantInformation{
+					{LanguagePreference: "ja"},
+				},
+			},
+			wantErr:    true,
+			wantID:     "64",
+			wantReason: mpm.ReasonMissing,
+		},
+		{
+			name: "err: tag of RFUForEMVCo is out of range",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+				RFUForEMVCo: []tlv.TLV{
+					{Tag: "80", Length: "04", Value: "abcd"},
+				},
+			},
+			wantErr:    true,
+			wantID:     "80",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: RFUForEMVCo is empty",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+				RFUForEMVCo: []tlv.TLV{
+					{Tag: "65", Length: "00"},
+				},
+			},
+			wantErr:    true,
+			wantID:     "65",
+			wantReason: mpm.ReasonMissing,
+		},
+		{
+			name: "pass: UnreservedTemplates",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+				UnreservedTemplates: []tlv.TLV{
+					{Tag: "80", Length: "32", Value: "0016A011223344998877070812345678"},
+				},
+			},
+		},
+		{
+			name: "err: UnreservedTemplates is malformed",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+				UnreservedTemplates: []tlv.TLV{
+					{Tag: "80", Length: "06", Value: "001612"},
+				},
+			},
+			wantErr:    true,
+			wantID:     "80",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: UnreservedTemplates lacks GloballyUniqueIdentifier",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+				UnreservedTemplates: []tlv.TLV{
+					{Tag: "80", Length: "12", Value: "070812345678"},
+				},
+			},
+			wantErr:    true,
+			wantID:     "80.00",
+			wantReason: mpm.ReasonMissing,
+		},
+		{
+			name: "pass: Others",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+				Others: []tlv.TLV{
+					{Tag: "AB", Length: "04", Value: "test"},
+				},
+			},
+		},
+		{
+			name: "err: Others holds PayloadFormatIndicator",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+				Others: []tlv.TLV{
+					{Tag: "00", Length: "02", Value: "01"},
+				},
+			},
+			wantErr:                true,
+			wantID:                 "00",
+			wantReason:             mpm.ReasonUnexpected,
+			wantSkipConformanceErr: true,
+		},
+		{
+			name: "err: Others holds CRC",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+				Others: []tlv.TLV{
+					{Tag: "63", Length: "04", Value: "ABCD"},
+				},
+			},
+			wantErr:                true,
+			wantID:                 "63",
+			wantReason:             mpm.ReasonUnexpected,
+			wantSkipConformanceErr: true,
+		},
+		{
+			name: "err: Others holds MerchantName",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+				Others: []tlv.TLV{
+					{Tag: "59", Length: "04", Value: "EVIL"},
+				},
+			},
+			wantErr:                true,
+			wantID:                 "59",
+			wantReason:             mpm.ReasonUnexpected,
+			wantSkipConformanceErr: true,
+		},
+		{
+			name: "err: Others tag is too long",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+				Others: []tlv.TLV{
+					{Tag: "ABC", Length: "04", Value: "test"},
+				},
+			},
+			wantErr:                true,
+			wantID:                 "ABC",
+			wantReason:             mpm.ReasonValue,
+			wantSkipConformanceErr: true,
+		},
+		{
+			name: "err: Others Length does not match Value",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+				Others: []tlv.TLV{
+					{Tag: "AB", Length: "05", Value: "test"},
+				},
+			},
+			wantErr:                true,
+			wantID:                 "AB",
+			wantReason:             mpm.ReasonLength,
+			wantSkipConformanceErr: true,
+		},
+		{
+			name: "err: Others is empty",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+				Others: []tlv.TLV{
+					{Tag: "AB", Length: "", Value: ""},
+				},
+			},
+			wantErr:                true,
+			wantID:                 "AB",
+			wantReason:             mpm.ReasonMissing,
+			wantSkipConformanceErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			buf, err := mpm.Encode(tt.give)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("Encode() error = %v", err)
+				}
+				if _, err := mpm.Decode(buf); err != nil {
+					t.Errorf("Decode() error = %v", err)
+				}
+				return
+			}
+
+			var e *mpm.FieldError
+			if !errors.As(err, &e) {
+				t.Fatalf("Encode() error = %v, want *mpm.FieldError", err)
+			}
+			if e.ID != tt.wantID || e.Reason != tt.wantReason {
+				t.Errorf("Encode() error ID = %s, Reason = %s, want %s, %s: %v", e.ID, e.Reason, tt.wantID, tt.wantReason, err)
+			}
+
+			if _, err := (&mpm.Encoder{SkipConformance: true}).Encode(tt.give); (err != nil) != tt.wantSkipConformanceErr {
+				t.Errorf("Encoder.Encode() with SkipConformance error = %v, wantErr %v", err, tt.wantSkipConformanceErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Package duitnow implements encoding and decoding of DuitNow QR, the Malaysian national QR standard by PayNet.
+*/
+package duitnow
+
+import (
+	"errors"
+	"fmt"
+
+	"go.mercari.io/go-emv-code/mpm"
+)
+
+const (
+	countryCode         = "MY"
+	transactionCurrency = "458"
+)
+
+var validators = []mpm.ValidatorFunc{
+	validateID,
+	validateAdditionalData,
+	mpm.CountryCodeValidator(countryCode),
+	mpm.TransactionCurrencyValidator(transactionCurrency),
+}
+
+// Decode decodes payload and validates as DuitNow QR.
+func Decode(payload []byte) (*mpm.Code, error) {
+	c, err := mpm.Decode(payload, validators...)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Encode encodes to EMV Payment Code payload.
+func Encode(c *mpm.Code) ([]byte, error) {
+	return mpm.Encode(c, validators...)
+}
+
+// WithAmount returns a copy of the static DuitNow QR c as a dynamic one of amount in MYR.
+func WithAmount(c *mpm.Code, amount mpm.Amount) (*mpm.Code, error) {
+	d, err := c.WithAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+	for _, vf := range validators {
+		if err := vf(d); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func validateID(c *mpm.Code) error {
+	_, err := ParseID(c)
+	if errors.Is(err, errMissingID) {
+		return mpm.NewFieldError("", "MerchantAccountInformation", "", mpm.ReasonMissing, fmt.Sprintf("duitnow: %s", err))
+	}
+	return err
+}
+
+func validateAdditionalData(c *mpm.Code) error {
+	_, _, err := ParseAdditionalData(c)
+	return err
+}
@@ -0,0 +1,244 @@
+package duitnow_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/mpm/duitnow"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	tests := []struct {
+		name       string
+		give       *mpm.Code
+		wantErr    bool
+		wantID     string
+		wantReason mpm.Reason
+	}{
+		{
+			name: "pass",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "44", Value: "0014A000000615000101068900530212123456789012"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "458",
+				CountryCode:          "MY",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "KUALA LUMPUR",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					PaymentSystemSpecificTemplates: []tlv.TLV{
+						{Tag: "80", Length: "29", Value: "0014A00000061500010107REF0001"},
+					},
+				},
+			},
+		},
+		{
+			name: "pass: without additional data",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "44", Value: "0014A000000615000101068900530212123456789012"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "458",
+				CountryCode:          "MY",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "KUALA LUMPUR",
+			},
+		},
+		{
+			name: "err: missing DuitNow ID",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "5123456789012345"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "458",
+				CountryCode:          "MY",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "KUALA LUMPUR",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					PaymentSystemSpecificTemplates: []tlv.TLV{
+						{Tag: "80", Length: "29", Value: "0014A00000061500010107REF0001"},
+					},
+				},
+			},
+			wantErr:    true,
+			wantID:     "",
+			wantReason: mpm.ReasonMissing,
+		},
+		{
+			name: "err: invalid participant ID",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "43", Value: "0014A00000061500010105890050212123456789012"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "458",
+				CountryCode:          "MY",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "KUALA LUMPUR",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					PaymentSystemSpecificTemplates: []tlv.TLV{
+						{Tag: "80", Length: "29", Value: "0014A00000061500010107REF0001"},
+					},
+				},
+			},
+			wantErr:    true,
+			wantID:     "26",
+			wantReason: mpm.ReasonLength,
+		},
+		{
+			name: "err: CountryCode is not MY",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "44", Value: "0014A000000615000101068900530212123456789012"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "458",
+				CountryCode:          "SG",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "KUALA LUMPUR",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					PaymentSystemSpecificTemplates: []tlv.TLV{
+						{Tag: "80", Length: "29", Value: "0014A00000061500010107REF0001"},
+					},
+				},
+			},
+			wantErr:    true,
+			wantID:     "58",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: TransactionCurrency is not MYR",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "44", Value: "0014A000000615000101068900530212123456789012"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "702",
+				CountryCode:          "MY",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "KUALA LUMPUR",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					PaymentSystemSpecificTemplates: []tlv.TLV{
+						{Tag: "80", Length: "29", Value: "0014A00000061500010107REF0001"},
+					},
+				},
+			},
+			wantErr:    true,
+			wantID:     "53",
+			wantReason: mpm.ReasonValue,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			buf, err := duitnow.Encode(tt.give)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				var fe *mpm.FieldError
+				if !errors.As(err, &fe) || fe.ID != tt.wantID || fe.Reason != tt.wantReason {
+					t.Errorf("Encode() error = %#v, want ID %s of %s", err, tt.wantID, tt.wantReason)
+				}
+				buf, _ = (&mpm.Encoder{SkipConformance: true}).Encode(tt.give)
+				_, err := duitnow.Decode(buf)
+				if !errors.As(err, &fe) || fe.ID != tt.wantID || fe.Reason != tt.wantReason {
+					t.Errorf("Decode() error = %#v, want ID %s of %s", err, tt.wantID, tt.wantReason)
+				}
+				return
+			}
+			got, err := duitnow.Decode(buf)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.MerchantAccountInformation, tt.give.MerchantAccountInformation) {
+				t.Errorf("Decode() = %+v, want %+v", got.MerchantAccountInformation, tt.give.MerchantAccountInformation)
+			}
+		})
+	}
+}
+
+func TestParseAdditionalData(t *testing.T) {
+	c := &mpm.Code{
+		PayloadFormatIndicator:  "01",
+		PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+		MerchantAccountInformation: []tlv.TLV{
+			{Tag: "26", Length: "44", Value: "0014A000000615000101068900530212123456789012"},
+		},
+		MerchantCategoryCode: "5812",
+		TransactionCurrency:  "458",
+		CountryCode:          "MY",
+		MerchantName:         "MERPAY CAFE",
+		MerchantCity:         "KUALA LUMPUR",
+		AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+			PaymentSystemSpecificTemplates: []tlv.TLV{
+				{Tag: "80", Length: "29", Value: "0014A00000061500010107REF0001"},
+			},
+		},
+	}
+	got, ok, err := duitnow.ParseAdditionalData(c)
+	if err != nil || !ok {
+		t.Fatalf("ParseAdditionalData() = %v, %v", ok, err)
+	}
+	if v, _ := got.Value("01"); got.ID != "80" || v != "REF0001" {
+		t.Errorf("ParseAdditionalData() = %+v, want ID 80 with REF0001", got)
+	}
+
+	c.AdditionalDataFieldTemplate.PaymentSystemSpecificTemplates = []tlv.TLV{{Tag: "81", Length: "29", Value: "0014a00000061500010107REF0002"}}
+	if got, ok, err := duitnow.ParseAdditionalData(c); err != nil || !ok || got.ID != "81" {
+		t.Errorf("ParseAdditionalData() of lowercase AID = %+v, %v, %v, want ID 81", got, ok, err)
+	}
+
+	c.AdditionalDataFieldTemplate.PaymentSystemSpecificTemplates = []tlv.TLV{{Tag: "50", Length: "16", Value: "0012D15600000000"}}
+	if got, ok, err := duitnow.ParseAdditionalData(c); err != nil || ok || got != nil {
+		t.Errorf("ParseAdditionalData() of another GUID = %+v, %v, %v, want nil, false, nil", got, ok, err)
+	}
+}
+
+func TestWithAmount(t *testing.T) {
+	c := &mpm.Code{
+		PayloadFormatIndicator:  "01",
+		PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+		MerchantAccountInformation: []tlv.TLV{
+			{Tag: "26", Length: "44", Value: "0014A000000615000101068900530212123456789012"},
+		},
+		MerchantCategoryCode: "5812",
+		TransactionCurrency:  "458",
+		CountryCode:          "MY",
+		MerchantName:         "MERPAY CAFE",
+		MerchantCity:         "KUALA LUMPUR",
+		AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+			PaymentSystemSpecificTemplates: []tlv.TLV{
+				{Tag: "80", Length: "29", Value: "0014A00000061500010107REF0001"},
+			},
+		},
+	}
+	got, err := duitnow.WithAmount(c, mpm.Amount{MinorUnits: 1050, Exponent: 2})
+	if err != nil {
+		t.Fatalf("WithAmount() error = %v", err)
+	}
+	if !got.IsDynamic() || got.TransactionAmount != (mpm.NullString{String: "10.50", Valid: true}) {
+		t.Errorf("WithAmount() = %+v, want dynamic DuitNow QR of 10.50", got)
+	}
+	if _, err := duitnow.WithAmount(c, mpm.Amount{MinorUnits: 1050, Exponent: 0}); err == nil {
+		t.Error("WithAmount() of another exponent error = nil, want error")
+	}
+}
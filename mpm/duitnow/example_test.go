@@ -0,0 +1,47 @@
+package duitnow_test
+
+import (
+	"fmt"
+	"log"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/mpm/duitnow"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+func ExampleParseID() {
+	id := duitnow.ID{ParticipantID: "890053", Account: "601234567890", ProxyType: duitnow.ProxyTypeMobile}
+	c := mpm.Code{
+		PayloadFormatIndicator:     "01",
+		PointOfInitiationMethod:    mpm.PointOfInitiationMethodStatic,
+		MerchantAccountInformation: []tlv.TLV{{Tag: "26", Value: id.String()}},
+		MerchantCategoryCode:       "5812",
+		TransactionCurrency:        "458",
+		CountryCode:                "MY",
+		MerchantName:               "MERPAY CAFE",
+		MerchantCity:               "KUALA LUMPUR",
+	}
+
+	d, err := duitnow.WithAmount(&c, mpm.Amount{MinorUnits: 1050, Exponent: 2})
+	if err != nil {
+		log.Fatal(err)
+	}
+	buf, err := duitnow.Encode(d)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	dst, err := duitnow.Decode(buf)
+	if err != nil {
+		log.Fatal(err)
+	}
+	got, err := duitnow.ParseID(dst)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%+v %s\n", *got, dst.TransactionAmount.String)
+
+	// Output:
+	// {ParticipantID:890053 Account:601234567890 ProxyType:MBNO} 10.50
+}
@@ -0,0 +1,160 @@
+package duitnow
+
+import (
+	"errors"
+	"fmt"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+const (
+	aid = "A0000006150001"
+
+	participantIDLength      = 6
+	accountMaxLength         = 30
+	additionalDataTemplateID = "80"
+)
+
+// ProxyType represents the type of DuitNow ID.
+type ProxyType string
+
+const (
+	ProxyTypeMobile               ProxyType = "MBNO" // mobile number
+	ProxyTypeNRIC                 ProxyType = "NRIC" // identity card number
+	ProxyTypePassport             ProxyType = "PSPT" // passport number
+	ProxyTypeArmyPolice           ProxyType = "ARMN" // army or police number
+	ProxyTypeBusinessRegistration ProxyType = "BREG" // business registration number
+)
+
+// ID represents a parsed DuitNow Merchant Account Information template.
+type ID struct {
+	ParticipantID string    // institution code of the acquiring participant
+	Account       string    // DuitNow ID or account number of the merchant
+	ProxyType     ProxyType // optional, empty if Account is an account number
+}
+
+// String returns the accumulated string.
+func (i *ID) String() string {
+	m := mpm.MerchantAccountInformationTemplate{
+		GloballyUniqueIdentifier: aid,
+		Data:                     []tlv.TLV{{Tag: "01", Value: i.ParticipantID}, {Tag: "02", Value: i.Account}},
+	}
+	if i.ProxyType != "" {
+		m.Data = append(m.Data, tlv.TLV{Tag: "03", Value: string(i.ProxyType)})
+	}
+	v, _ := m.Tokenize()
+	return v
+}
+
+// validateIDFormat validates i of the Merchant Account Information template of id.
+func validateIDFormat(id string, i *ID) error {
+	if len(i.ParticipantID) != participantIDLength {
+		return idError(id, i.ParticipantID, mpm.ReasonLength, fmt.Sprintf("participant ID should be %d digits", participantIDLength))
+	}
+	if !mpm.IsNumeric(i.ParticipantID) {
+		return idError(id, i.ParticipantID, mpm.ReasonValue, fmt.Sprintf("participant ID should be %d digits", participantIDLength))
+	}
+	if i.Account == "" || accountMaxLength < len(i.Account) {
+		return idError(id, i.Account, mpm.ReasonLength, fmt.Sprintf("len(Account) should be between 1 and %d", accountMaxLength))
+	}
+	switch i.ProxyType {
+	case "", ProxyTypeMobile, ProxyTypeNRIC, ProxyTypePassport, ProxyTypeArmyPolice, ProxyTypeBusinessRegistration:
+	default:
+		return idError(id, string(i.ProxyType), mpm.ReasonValue, fmt.Sprintf("unknown proxy type %q", i.ProxyType))
+	}
+	return nil
+}
+
+func idError(id, value string, reason mpm.Reason, msg string) error {
+	return mpm.NewFieldError(id, "MerchantAccountInformation", value, reason, "duitnow: "+msg)
+}
+
+var matchAID = mpm.MatchGUID(aid)
+
+func init() {
+	mpm.RegisterScheme(mpm.Scheme{
+		Name:  "DuitNow",
+		Match: matchAID,
+		Parse: parseID,
+	})
+}
+
+func parseID(m *mpm.MerchantAccountInformationTemplate) (interface{}, error) {
+	var id ID
+	id.ParticipantID, _ = m.Value("01")
+	id.Account, _ = m.Value("02")
+	v, _ := m.Value("03")
+	id.ProxyType = ProxyType(v)
+	if err := validateIDFormat(m.ID, &id); err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+var errMissingID = errors.New("missing DuitNow ID")
+
+// ParseID validates and parses given *mpm.Code as DuitNow ID.
+// If the DuitNow ID is malformed, it returns *mpm.FieldError of the ID of the template.
+func ParseID(c *mpm.Code) (*ID, error) {
+	v, err := c.AccountInformation(aid)
+	if errors.Is(err, mpm.ErrAccountInformationNotFound) {
+		return nil, errMissingID
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v.(*ID), nil
+}
+
+// ParseIDFromString validates and parses given string as DuitNow ID.
+func ParseIDFromString(v string) (*ID, error) {
+	t, err := tlv.New("26", v)
+	if err != nil {
+		return nil, err
+	}
+	return ParseID(&mpm.Code{MerchantAccountInformation: []tlv.TLV{t}})
+}
+
+// AdditionalData represents the DuitNow payment system specific template of Additional Data Field Template.
+type AdditionalData struct {
+	ID   string    // ID of the template, 50–99
+	Data []tlv.TLV // ID 01–99, in order of appearance
+}
+
+// Value returns the value of the data object of tag.
+func (a *AdditionalData) Value(tag string) (string, bool) {
+	for _, t := range a.Data {
+		if t.Tag == tag {
+			return t.Value, true
+		}
+	}
+	return "", false
+}
+
+// TLV returns a as a tlv.TLV of mpm.AdditionalDataFieldTemplate.PaymentSystemSpecificTemplates.
+// ID defaults to 80.
+func (a *AdditionalData) TLV() (tlv.TLV, error) {
+	id := a.ID
+	if id == "" {
+		id = additionalDataTemplateID
+	}
+	m := mpm.MerchantAccountInformationTemplate{ID: id, GloballyUniqueIdentifier: aid, Data: a.Data}
+	return m.TLV()
+}
+
+// ParseAdditionalData parses the DuitNow payment system specific template of given *mpm.Code.
+// It returns false if the Code has no such template, and *mpm.FieldError of the ID of a malformed template, e.g. "62.80".
+func ParseAdditionalData(c *mpm.Code) (*AdditionalData, bool, error) {
+	for _, t := range c.AdditionalDataFieldTemplate.PaymentSystemSpecificTemplates {
+		// Payment system specific templates share the layout of Merchant Account Information templates.
+		m := mpm.MerchantAccountInformationTemplate{ID: t.Tag}
+		if err := m.Scan([]rune(t.Value)); err != nil {
+			return nil, false, mpm.NewFieldError("62."+t.Tag, "AdditionalDataFieldTemplate.PaymentSystemSpecificTemplates", t.Value, mpm.ReasonValue, fmt.Sprintf("duitnow: failed to parse payment system specific template %s: %s", t.Tag, err))
+		}
+		if matchAID(m.GloballyUniqueIdentifier) {
+			return &AdditionalData{ID: m.ID, Data: m.Data}, true, nil
+		}
+	}
+	return nil, false, nil
+}
@@ -0,0 +1,63 @@
+package duitnow_test
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mercari.io/go-emv-code/mpm/duitnow"
+)
+
+func TestParseIDFromString(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    *duitnow.ID
+		wantErr bool
+	}{
+		{
+			name:  "pass: account number",
+			value: "0014A000000615000101068900530212123456789012",
+			want:  &duitnow.ID{ParticipantID: "890053", Account: "123456789012"},
+		},
+		{
+			name:  "pass: DuitNow ID",
+			value: "0014A000000615000101068900530212601234567890" + "0304MBNO",
+			want:  &duitnow.ID{ParticipantID: "890053", Account: "601234567890", ProxyType: duitnow.ProxyTypeMobile},
+		},
+		{
+			name:    "fail: invalid participant ID",
+			value:   "0014A00000061500010105890050212123456789012",
+			wantErr: true,
+		},
+		{
+			name:    "fail: missing account",
+			value:   "0014A00000061500010106890053",
+			wantErr: true,
+		},
+		{
+			name:    "fail: unknown proxy type",
+			value:   "0014A0000006150001010689005302121234567890120304XXXX",
+			wantErr: true,
+		},
+		{
+			name:    "fail: another GUID",
+			value:   "0016A0000006770101110213123456789012",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := duitnow.ParseIDFromString(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseIDFromString() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseIDFromString() = %+v, want %+v", got, tt.want)
+			}
+			if got != nil && got.String() != tt.value {
+				t.Errorf("ID.String() = %s, want %s", got.String(), tt.value)
+			}
+		})
+	}
+}
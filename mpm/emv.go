@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"reflect"
 	"strconv"
 	"strings"
 	"unicode/utf8"
@@ -15,23 +16,28 @@ import (
 
 // Code represents EMV Payment Code payload structure.
 type Code struct {
-	PayloadFormatIndicator          string                    `emv:"00"` // The first data object
-	PointOfInitiationMethod         PointOfInitiationMethod   `emv:"01"`
-	MerchantAccountInformation      []tlv.TLV                 `emv:"MerchantAccountInformation"`
-	MerchantCategoryCode            string                    `emv:"52"`
-	TransactionCurrency             string                    `emv:"53"`
-	TransactionAmount               NullString                `emv:"54"`
-	TipOrConvenienceIndicator       TipOrConvenienceIndicator `emv:"55"`
-	ValueOfConvenienceFeeFixed      NullString                `emv:"56"`
-	ValueOfConvenienceFeePercentage NullString                `emv:"57"`
-	CountryCode                     string                    `emv:"58"`
-	MerchantName                    string                    `emv:"59"`
-	MerchantCity                    string                    `emv:"60"`
-	PostalCode                      string                    `emv:"61"`
-	AdditionalDataFieldTemplate     string                    `emv:"62"`
+	PayloadFormatIndicator          string                      `emv:"00"` // The first data object
+	PointOfInitiationMethod         PointOfInitiationMethod     `emv:"01"`
+	MerchantAccountInformation      []tlv.TLV                   `emv:"MerchantAccountInformation"`
+	MerchantCategoryCode            string                      `emv:"52"`
+	TransactionCurrency             string                      `emv:"53"`
+	TransactionAmount               NullString                  `emv:"54"`
+	TipOrConvenienceIndicator       TipOrConvenienceIndicator   `emv:"55"`
+	ValueOfConvenienceFeeFixed      NullString                  `emv:"56"`
+	ValueOfConvenienceFeePercentage NullString                  `emv:"57"`
+	CountryCode                     string                      `emv:"58"`
+	MerchantName                    string                      `emv:"59"`
+	MerchantCity                    string                      `emv:"60"`
+	PostalCode                      string                      `emv:"61"`
+	AdditionalDataFieldTemplate     AdditionalDataFieldTemplate `emv:"62"`
 	// CRC                             string  `emv:"63"` // The last object under the root. But useless for value.
-	MerchantInformation NullMerchantInformation `emv:"64"`
-	UnreservedTemplates []tlv.TLV               `emv:"UnreservedTemplates"`
+	MerchantInformation          NullMerchantInformation      `emv:"64"`
+	AlternateMerchantInformation AlternateMerchantInformation `emv:"AlternateMerchantInformation"` // ID 64 following the first one
+	RFUForEMVCo                  []tlv.TLV                    `emv:"RFUForEMVCo"`                  // ID 65–79
+	UnreservedTemplates          []tlv.TLV                    `emv:"UnreservedTemplates"`
+	Others                       []tlv.TLV                    `emv:"*"` // data objects of unknown IDs, written as is
+
+	raw []tlv.TLV // root data objects in the order of the decoded payload followed by CRC, see Decoder.PreserveOrder
 }
 
 const (
@@ -48,14 +54,22 @@ const (
 	payloadFormatIndicator    = payloadFormatIndicatorID + "0201"
 	payloadFormatIndicatorLen = len(payloadFormatIndicator)
 
+	additionalDataFieldTemplateID = "62"
+
 	crcID           = "63"
 	crcIDLengthRepr = crcID + "04"
 	crcValueLen     = 4
 	crcLen          = len(crcIDLengthRepr) + crcValueLen
 
-	merchantAccountInformationIDFrom  = 2
-	merchantAccountInformationIDTo    = 51
-	merchantAccountInformationTagName = "MerchantAccountInformation"
+	merchantAccountInformationIDFrom = 2
+	// merchantAccountInformationTemplateIDFrom is the first ID of templates. IDs before it are primitive data objects reserved for payment networks.
+	merchantAccountInformationTemplateIDFrom = 26
+	merchantAccountInformationIDTo           = 51
+	merchantAccountInformationTagName        = "MerchantAccountInformation"
+
+	rfuForEMVCoIDFrom  = 65
+	rfuForEMVCoIDTo    = 79
+	rfuForEMVCoTagName = "RFUForEMVCo"
 
 	unreservedTemplatesIDFrom  = 80
 	unreservedTemplatesIDTo    = 99
@@ -71,31 +85,98 @@ func chainTagLengthTranslators(f ...func(srcTagName, srcLength []rune) ([]rune,
 	})
 }
 
-func merchantAccountInformation(tag, length []rune) ([]rune, []rune) {
-	id, _ := strconv.Atoi(string(tag))
-	if (id >= merchantAccountInformationIDFrom) && (id <= merchantAccountInformationIDTo) {
-		return []rune(merchantAccountInformationTagName), length
+// idRangeTranslator folds every tag between from and to into the pseudo tag name.
+func idRangeTranslator(from, to int, tagName string) func(tag, length []rune) ([]rune, []rune) {
+	return func(tag, length []rune) ([]rune, []rune) {
+		id, err := strconv.Atoi(string(tag))
+		if err == nil && (id >= from) && (id <= to) {
+			return []rune(tagName), length
+		}
+		return tag, length
 	}
-	return tag, length
 }
 
-func unreservedTemplates(tag, length []rune) ([]rune, []rune) {
-	id, _ := strconv.Atoi(string(tag))
-	if (id >= unreservedTemplatesIDFrom) && (id <= unreservedTemplatesIDTo) {
-		return []rune(unreservedTemplatesTagName), length
+// pseudoTagTranslator erases the pseudo tag name and its length so that
+// each tlv.TLV of the field is written with its own Tag and Length.
+func pseudoTagTranslator(tagName string) func(tag, length []rune) ([]rune, []rune) {
+	return func(tag, length []rune) ([]rune, []rune) {
+		if string(tag) == tagName {
+			tag = []rune{}
+			length = []rune{}
+		}
+		return tag, length
 	}
-	return tag, length
 }
 
+var (
+	merchantAccountInformation = idRangeTranslator(merchantAccountInformationIDFrom, merchantAccountInformationIDTo, merchantAccountInformationTagName)
+	rfuForEMVCo                = idRangeTranslator(rfuForEMVCoIDFrom, rfuForEMVCoIDTo, rfuForEMVCoTagName)
+	unreservedTemplates        = idRangeTranslator(unreservedTemplatesIDFrom, unreservedTemplatesIDTo, unreservedTemplatesTagName)
+
+	merchantAccountInformationTagLengthTranslator = pseudoTagTranslator(merchantAccountInformationTagName)
+	unreservedTemplatesTagLengthTranslator        = pseudoTagTranslator(unreservedTemplatesTagName)
+)
+
 // ValidatorFunc is an adapter for functions as validator.
+// A validator may return multiple problems at once by returning Errors or errors.Join.
 type ValidatorFunc func(*Code) error
 
+// Decoder decodes EMV MPM payload. The zero value is ready to use.
+type Decoder struct {
+	// CollectAllErrors makes Decode run every field check and validator and
+	// return every problem as Errors instead of stopping at the first one.
+	CollectAllErrors bool
+	// SkipConformance disables ConformanceValidators. Only given validators are run.
+	SkipConformance bool
+	// LengthUnit is the unit in which Lengths count Values. The zero value counts characters.
+	// MaxSize is enforced in the same unit.
+	LengthUnit tlv.LengthUnit
+	// FieldLengthUnits overrides LengthUnit for the data objects of the root IDs, e.g. "64",
+	// and the data objects nested in them.
+	FieldLengthUnits map[string]tlv.LengthUnit
+	// AllowDuplicateTags accepts payloads in which a data object appears more than once.
+	// By default such payloads are rejected since the later one would silently override the former.
+	// Merchant Information—Language Template (ID 64) may always be repeated, see AlternateMerchantInformation.
+	AllowDuplicateTags bool
+	// PreserveOrder makes the decoded Code retain the order and the raw bytes of the root data objects
+	// so that Encode reproduces the payload as is. See Code.DataObjects.
+	// The CRC is retained as well, e.g. in lowercase, and written as is while it matches the encoded content.
+	PreserveOrder bool
+}
+
+// Encoder encodes EMV MPM payload. The zero value is ready to use.
+type Encoder struct {
+	// CollectAllErrors makes Encode run every validator and
+	// return every problem as Errors instead of stopping at the first one.
+	CollectAllErrors bool
+	// SkipConformance disables ConformanceValidators. Only given validators and the check of Others,
+	// which are written as is, are run.
+	SkipConformance bool
+	// LengthUnit is the unit in which Lengths count Values. The zero value counts characters.
+	// MaxSize is enforced in the same unit.
+	LengthUnit tlv.LengthUnit
+	// FieldLengthUnits overrides LengthUnit for the data objects of the root IDs, e.g. "64",
+	// and the data objects nested in them.
+	FieldLengthUnits map[string]tlv.LengthUnit
+	// Canonicalize makes Encode write the root data objects in the order of the specification
+	// even if the Code retains the order of the decoded payload.
+	Canonicalize bool
+}
+
 // Decode decodes payload and validates as EMV MPM.
 func Decode(payload []byte, vfs ...ValidatorFunc) (*Code, error) {
+	return (&Decoder{}).Decode(payload, vfs...)
+}
+
+// Decode decodes payload and validates as EMV MPM.
+func (d *Decoder) Decode(payload []byte, vfs ...ValidatorFunc) (*Code, error) {
 	l := len(payload)
 	if l < crcLen {
 		return nil, NewInvalidFormat("mpm: too short payload")
 	}
+	if size := d.LengthUnit.Len(string(payload)); MaxSize < size {
+		return nil, NewInvalidFormat(fmt.Sprintf("mpm: payload of %d %s exceeds %d", size, d.LengthUnit, MaxSize))
+	}
 
 	if string(payload[:payloadFormatIndicatorLen]) != payloadFormatIndicator {
 		return nil, NewInvalidFormat(fmt.Sprintf("mpm: first %d bytes should be match %s", payloadFormatIndicatorLen, payloadFormatIndicator))
@@ -105,71 +186,241 @@ func Decode(payload []byte, vfs ...ValidatorFunc) (*Code, error) {
 		return nil, NewInvalidFormat(fmt.Sprintf("mpm: last %d bytes should be represents CRC. got %s", crcLen, string(payload[l-crcLen:])))
 	}
 
+	var errs []error
+
 	crc := crc16.ChecksumCCITTFalse([]byte(string(payload[:l-crcValueLen])))
 	if got, _ := strconv.ParseUint(string(payload[l-crcValueLen:l]), 16, 64); uint16(got) != crc {
-		return nil, NewInvalidCRC(crc, uint16(got))
+		if !d.CollectAllErrors {
+			return nil, NewInvalidCRC(crc, uint16(got))
+		}
+		errs = append(errs, NewInvalidCRC(crc, uint16(got)))
+	}
+
+	for _, err := range d.structureErrors(payload[:l-crcLen]) {
+		if !d.CollectAllErrors {
+			return nil, err
+		}
+		errs = append(errs, err)
 	}
 
 	var c Code
 	translatorFunc := chainTagLengthTranslators(
 		merchantAccountInformation,
+		rfuForEMVCo,
 		unreservedTemplates,
 	)
-	if err := tlv.NewDecoder(bytes.NewReader(payload), tagName, MaxSize, tagLength, lenLength, translatorFunc).Decode(&c); err != nil {
-		switch e := err.(type) {
-		case *tlv.MalformedPayloadError:
-			return nil, NewInvalidFormat(fmt.Sprintf("mpm: %s", e.Error()))
+	// CRC is not a field of Code.
+	dec := tlv.NewDecoder(bytes.NewReader(payload[:l-crcLen]), tagName, MaxSize, tagLength, lenLength, translatorFunc)
+	setLengthUnits(dec, d.LengthUnit, d.FieldLengthUnits)
+	if d.CollectAllErrors {
+		dec.CollectAllErrors()
+	}
+	if !d.AllowDuplicateTags {
+		// Misplaced Payload Format Indicators are reported by structureErrors.
+		dec.RejectDuplicateTags(merchantInformationID, payloadFormatIndicatorID)
+	}
+	if err := dec.Decode(&c); err != nil {
+		var malformed bool
+		for _, e := range appendErrors(nil, err) {
+			if _, ok := e.(*tlv.MalformedPayloadError); ok {
+				malformed = true
+			}
+			errs = append(errs, decodeError(e))
+		}
+		if !d.CollectAllErrors {
+			return nil, errs[0]
+		}
+		// Validating a partially decoded Code only reports spurious problems.
+		if malformed {
+			return nil, Errors(errs)
 		}
-		return nil, err
 	}
 
-	vfs = append(
-		vfs,
-		validateMerchantName,
-		validateMerchantCity,
-		validateMerchantInformation,
-		validateUnreservedTemplates,
-	)
-	for _, f := range vfs {
-		if err := f(&c); err != nil {
-			return nil, err
+	if !d.AllowDuplicateTags {
+		for _, err := range d.nestedDuplicateErrors(payload[:l-crcLen]) {
+			if !d.CollectAllErrors {
+				return nil, err
+			}
+			errs = append(errs, err)
 		}
 	}
 
+	if d.PreserveOrder {
+		if raw, ok := splitDataObjects(payload[payloadFormatIndicatorLen:l-crcLen], d.lengthUnitOf); ok {
+			c.raw = append(raw, tlv.TLV{Tag: crcID, Length: crcIDLengthRepr[tagLength:], Value: string(payload[l-crcValueLen:])})
+		}
+	}
+
+	d.decodeAlternateMerchantInformation(&c, payload[:l-crcLen])
+
+	if !d.SkipConformance {
+		vfs = append(vfs, conformanceValidators(d.lengthUnitOf)...)
+	}
+	for _, err := range validate(&c, vfs, d.CollectAllErrors) {
+		errs = append(errs, withOffset(err, payload, d.lengthUnitOf))
+	}
+
+	if len(errs) != 0 {
+		if !d.CollectAllErrors {
+			return nil, errs[0]
+		}
+		return nil, Errors(errs)
+	}
+
 	return &c, nil
 }
 
-func merchantAccountInformationTagLengthTranslator(tag, length []rune) ([]rune, []rune) {
-	if string(tag) == merchantAccountInformationTagName {
-		tag = []rune{}
-		length = []rune{}
+func decodeError(err error) error {
+	switch e := err.(type) {
+	case *tlv.MalformedPayloadError:
+		return NewInvalidFormat(fmt.Sprintf("mpm: %s", e.Error()))
+	case *tlv.ScanError:
+		return scanFieldError(e)
+	case *tlv.DuplicateTagError:
+		return duplicateFieldError(e.Tag, codeFieldName(e.Tag), e)
+	}
+	return err
+}
+
+func duplicateFieldError(id, field string, e *tlv.DuplicateTagError) error {
+	return &FieldError{
+		ID:     id,
+		Field:  field,
+		Offset: e.DuplicateOffset,
+		Reason: ReasonUnexpected,
+		msg:    fmt.Sprintf("mpm: %s is duplicated: %s", id, e.Error()),
+		cause:  e,
+	}
+}
+
+// structureErrors returns errors of root data objects out of place in payload without CRC.
+// Payload Format Indicator should be the first data object and CRC the last one, each appearing exactly once.
+func (d *Decoder) structureErrors(payload []byte) []error {
+	objs, ok := splitDataObjects(payload, d.lengthUnitOf)
+	if !ok {
+		return nil // reported by tlv.Decoder
+	}
+	var errs []error
+	var off int
+	for i, o := range objs {
+		switch {
+		case i != 0 && o.Tag == payloadFormatIndicatorID:
+			errs = append(errs, &FieldError{
+				ID:     o.Tag,
+				Field:  "PayloadFormatIndicator",
+				Value:  o.Value,
+				Offset: off,
+				Reason: ReasonUnexpected,
+				msg:    fmt.Sprintf("mpm: %s should be the first data object, got at %d", o.Tag, off),
+			})
+		case o.Tag == crcID:
+			errs = append(errs, &FieldError{
+				ID:     o.Tag,
+				Field:  "CRC",
+				Value:  o.Value,
+				Offset: off,
+				Reason: ReasonUnexpected,
+				msg:    fmt.Sprintf("mpm: %s should be the last data object, got at %d", o.Tag, off),
+			})
+		}
+		off += tagLength + lenLength + len(o.Value)
+	}
+	return errs
+}
+
+// nestedDuplicateErrors returns errors of data objects which appear more than once in a template.
+func (d *Decoder) nestedDuplicateErrors(payload []byte) []error {
+	objs, ok := splitDataObjects(payload, d.lengthUnitOf)
+	if !ok {
+		return nil
+	}
+	var errs []error
+	var off int
+	for _, o := range objs {
+		base := off + tagLength + lenLength
+		off = base + len(o.Value)
+		if !isTemplateID(o.Tag) {
+			continue
+		}
+		unit := d.lengthUnitOf(o.Tag)
+		nested, ok := splitDataObjects([]byte(o.Value), func(string) tlv.LengthUnit { return unit })
+		if !ok {
+			continue // reported by the Scanner of the template
+		}
+		seen := make(map[string]int, len(nested))
+		var nestedOff int
+		for _, v := range nested {
+			if first, ok := seen[v.Tag]; ok {
+				e := &tlv.DuplicateTagError{Tag: v.Tag, Offset: base + first, DuplicateOffset: base + nestedOff}
+				errs = append(errs, duplicateFieldError(o.Tag+"."+v.Tag, codeFieldName(o.Tag), e))
+			} else {
+				seen[v.Tag] = nestedOff
+			}
+			nestedOff += tagLength + lenLength + len(v.Value)
+		}
+	}
+	return errs
+}
+
+// isTemplateID reports whether the root data object of id is a template of nested data objects.
+func isTemplateID(id string) bool {
+	n, err := strconv.Atoi(id)
+	if err != nil || len(id) != tagLength {
+		return false
+	}
+	return id == additionalDataFieldTemplateID || id == merchantInformationID ||
+		(merchantAccountInformationTemplateIDFrom <= n && n <= merchantAccountInformationIDTo) ||
+		(unreservedTemplatesIDFrom <= n && n <= unreservedTemplatesIDTo)
+}
+
+// validate runs vfs against c. Unless collectAll, it stops at the first error.
+func validate(c *Code, vfs []ValidatorFunc, collectAll bool) []error {
+	var errs []error
+	for _, f := range vfs {
+		errs = appendErrors(errs, f(c))
+		if !collectAll && len(errs) != 0 {
+			return errs[:1]
+		}
 	}
-	return tag, length
+	return errs
 }
 
-func unreservedTemplatesTagLengthTranslator(tag, length []rune) ([]rune, []rune) {
-	if string(tag) == unreservedTemplatesTagName {
-		tag = []rune{}
-		length = []rune{}
+// appendErrors appends err to errs flattening errors which wrap multiple errors.
+func appendErrors(errs []error, err error) []error {
+	if err == nil {
+		return errs
+	}
+	if m, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range m.Unwrap() {
+			errs = appendErrors(errs, e)
+		}
+		return errs
 	}
-	return tag, length
+	return append(errs, err)
 }
 
 // Encode encodes to EMV Payment Code payload.
 func Encode(c *Code, vfs ...ValidatorFunc) ([]byte, error) {
+	return (&Encoder{}).Encode(c, vfs...)
+}
+
+// Encode encodes to EMV Payment Code payload.
+func (e *Encoder) Encode(c *Code, vfs ...ValidatorFunc) ([]byte, error) {
 	if c == nil {
 		return nil, errors.New("mpm: nil is not allowed")
 	}
 
-	vfs = append(
-		vfs,
-		validateMerchantInformation,
-		validateUnreservedTemplates,
-	)
-	for _, f := range vfs {
-		if err := f(c); err != nil {
-			return nil, err
+	if !e.SkipConformance {
+		vfs = append(vfs, conformanceValidators(e.lengthUnitOf)...)
+	} else {
+		// Others are written as is and would break the payload.
+		vfs = append(vfs, func(c *Code) error { return validateOthers(c, e.lengthUnitOf) })
+	}
+	if errs := validate(c, vfs, e.CollectAllErrors); len(errs) != 0 {
+		if !e.CollectAllErrors {
+			return nil, errs[0]
 		}
+		return nil, Errors(errs)
 	}
 
 	hash := crc16.NewCCITTFalse()
@@ -185,10 +436,22 @@ func Encode(c *Code, vfs ...ValidatorFunc) ([]byte, error) {
 
 	translatorFunc := chainTagLengthTranslators(
 		merchantAccountInformationTagLengthTranslator,
+		pseudoTagTranslator(alternateMerchantInformationTagName),
+		pseudoTagTranslator(rfuForEMVCoTagName),
 		unreservedTemplatesTagLengthTranslator,
 	)
-	if err := tlv.NewEncoder(w, tagName, []string{payloadFormatIndicatorID, crcID}, translatorFunc).Encode(c); err != nil {
-		return nil, fmt.Errorf("mpm: failed to encode: %s", err)
+	var body bytes.Buffer
+	enc := tlv.NewEncoder(&body, tagName, []string{payloadFormatIndicatorID, crcID}, translatorFunc)
+	setLengthUnits(enc, e.LengthUnit, e.FieldLengthUnits)
+	if err := enc.Encode(c); err != nil {
+		return nil, fmt.Errorf("mpm: failed to encode: %w", err)
+	}
+	b := body.Bytes()
+	if c.raw != nil && !e.Canonicalize {
+		b = e.reorderDataObjects(b, c.raw)
+	}
+	if _, err := w.Write(b); err != nil {
+		return nil, fmt.Errorf("mpm: failed to write body: %s", err)
 	}
 
 	// To calculate CRC, we need the ID and Length of the CRC itself.
@@ -197,61 +460,155 @@ func Encode(c *Code, vfs ...ValidatorFunc) ([]byte, error) {
 	}
 
 	crc := strings.ToUpper(fmt.Sprintf("%04x", hash.Sum16()))
+	if raw := c.rawCRC(); !e.Canonicalize && strings.EqualFold(raw, crc) {
+		// The content is unchanged, so keep the CRC of the decoded payload as is.
+		crc = raw
+	}
 	if _, err := w.Write([]byte(crc)); err != nil {
 		return nil, fmt.Errorf("mpm: failed to write CRC: %s", err)
 	}
 
+	if size := e.LengthUnit.Len(buf.String()); MaxSize < size {
+		return nil, NewInvalidFormat(fmt.Sprintf("mpm: payload of %d %s exceeds %d", size, e.LengthUnit, MaxSize))
+	}
+
 	return buf.Bytes(), nil
 }
 
-func validateMerchantName(c *Code) error {
-	if c.MerchantName == "" || 25 < utf8.RuneCountInString(c.MerchantName) {
-		return NewInvalidFormat("mpm: length of MerchantName should be between 1 and 25")
+// setLengthUnits sets unit and the units of the root IDs of fields to the codec.
+func setLengthUnits(codec interface {
+	LengthUnit(tlv.LengthUnit, ...string)
+}, unit tlv.LengthUnit, fields map[string]tlv.LengthUnit) {
+	codec.LengthUnit(unit)
+	for id, u := range fields {
+		codec.LengthUnit(u, id)
+		if id == merchantInformationID {
+			codec.LengthUnit(u, alternateMerchantInformationTagName)
+		}
 	}
-	return nil
 }
 
-func validateMerchantCity(c *Code) error {
-	if c.MerchantCity == "" || 15 < utf8.RuneCountInString(c.MerchantCity) {
-		return NewInvalidFormat("mpm: length of MerchantCity should be between 1 and 15")
+// decodeAlternateMerchantInformation keeps the first data object of ID 64 in c.MerchantInformation
+// and the following ones in c.AlternateMerchantInformation.
+// Malformed data objects of ID 64 are left to the errors reported by the root decoder.
+func (d *Decoder) decodeAlternateMerchantInformation(c *Code, payload []byte) {
+	var v struct {
+		MerchantInformation AlternateMerchantInformation `emv:"64"`
 	}
-	return nil
+	dec := tlv.NewDecoder(bytes.NewReader(payload), tagName, MaxSize, tagLength, lenLength, nil)
+	setLengthUnits(dec, d.LengthUnit, d.FieldLengthUnits)
+	if err := dec.Decode(&v); err != nil || len(v.MerchantInformation) < 2 {
+		return
+	}
+	c.MerchantInformation = v.MerchantInformation[0]
+	c.AlternateMerchantInformation = v.MerchantInformation[1:]
 }
 
-func validateMerchantInformation(c *Code) error {
-	if !c.MerchantInformation.Valid {
-		return nil
-	}
-	if len(c.MerchantInformation.LanguagePreference) != 2 {
-		return NewInvalidFormat("mpm: length of MerchantInformation.LanguagePreference should be 2")
+// lengthUnitOf returns the unit of the data objects of the root ID.
+func (d *Decoder) lengthUnitOf(id string) tlv.LengthUnit {
+	if u, ok := d.FieldLengthUnits[id]; ok {
+		return u
 	}
-	if c.MerchantInformation.Name == "" || 25 < utf8.RuneCountInString(c.MerchantInformation.Name) {
-		return NewInvalidFormat("mpm: length of MerchantInformation.Name should be between 1 and 25")
+	return d.LengthUnit
+}
+
+// lengthUnitOf returns the unit of the data objects of the root ID.
+func (e *Encoder) lengthUnitOf(id string) tlv.LengthUnit {
+	if u, ok := e.FieldLengthUnits[id]; ok {
+		return u
 	}
-	if 15 < utf8.RuneCountInString(c.MerchantInformation.City) {
-		return NewInvalidFormat("mpm: length of MerchantInformation.City should be less than 15")
+	return e.LengthUnit
+}
+
+// withOffset fills Offset of FieldError in err by looking up its ID in payload.
+// unitOf returns the unit of the data objects of a root ID.
+func withOffset(err error, payload []byte, unitOf func(id string) tlv.LengthUnit) error {
+	var e *FieldError
+	if errors.As(err, &e) && e.Offset < 0 && e.ID != "" {
+		e.Offset = dataObjectOffset(payload, e.ID, unitOf)
 	}
-	return nil
+	return err
 }
 
-func validateUnreservedTemplates(c *Code) error {
-	if len(c.UnreservedTemplates) == 0 {
-		return nil
+// dataObjectOffset returns the byte offset of the data object identified by id in payload.
+// If a nested data object is missing, the offset of its closest template is returned, or -1 if nothing found.
+func dataObjectOffset(payload []byte, id string, unitOf func(id string) tlv.LengthUnit) int {
+	base, found := 0, -1
+	for i, id := range strings.Split(id, ".") {
+		off, value, ok := findDataObject(payload, id, unitOf)
+		if !ok {
+			break
+		}
+		if i == 0 {
+			// nested data objects are counted in the unit of the root one.
+			unit := unitOf(id)
+			unitOf = func(string) tlv.LengthUnit { return unit }
+		}
+		found = base + off
+		base = found + tagLength + lenLength
+		payload = value
 	}
-	for _, t := range c.UnreservedTemplates {
-		var v struct {
-			GloballyUniqueIdentifier string `emv:"00"`
+	return found
+}
+
+func findDataObject(b []byte, id string, unitOf func(id string) tlv.LengthUnit) (int, []byte, bool) {
+	for i := 0; i+tagLength+lenLength <= len(b); {
+		l := string(b[i+tagLength : i+tagLength+lenLength])
+		if !IsNumeric(l) {
+			return 0, nil, false
 		}
-		if err := tlv.NewDecoder(strings.NewReader(t.Value), tagName, MaxSize, tagLength, lenLength, nil).Decode(&v); err != nil {
-			switch e := err.(type) {
-			case *tlv.MalformedPayloadError:
-				return NewInvalidFormat(fmt.Sprintf("mpm: %s", e.Error()))
+		length, _ := strconv.Atoi(l)
+		start := i + tagLength + lenLength
+		end := start
+		if unitOf(string(b[i:i+tagLength])) == tlv.LengthUnitByte {
+			end += length
+			if len(b) < end {
+				return 0, nil, false
 			}
-			return err
+		} else {
+			for n := 0; n < length; n++ {
+				_, size := utf8.DecodeRune(b[end:])
+				if size == 0 {
+					return 0, nil, false
+				}
+				end += size
+			}
+		}
+		if string(b[i:i+tagLength]) == id {
+			return i, b[start:end], true
 		}
-		if v.GloballyUniqueIdentifier == "" || 32 < len(v.GloballyUniqueIdentifier) {
-			return NewInvalidFormat("mpm: length of tag 00 of UnreversedTemplate should be between 1 and 32")
+		i = end
+	}
+	return 0, nil, false
+}
+
+// scanFieldError converts tlv.ScanError occurred while decoding a data object of Code into FieldError.
+func scanFieldError(e *tlv.ScanError) error {
+	id, field, value, offset := e.Tag, codeFieldName(e.Tag), e.Value, e.Offset
+	var inner *tlv.ScanError
+	if errors.As(e.Err, &inner) {
+		id += "." + inner.Tag
+		value = inner.Value
+		offset += tagLength + lenLength + inner.Offset
+	}
+	return &FieldError{
+		ID:     id,
+		Field:  field,
+		Value:  value,
+		Offset: offset,
+		Reason: ReasonValue,
+		msg:    fmt.Sprintf("mpm: invalid value of %s: %s", field, e.Err),
+	}
+}
+
+// codeFieldName returns the name of the field of Code for the root ID.
+func codeFieldName(id string) string {
+	tag, _ := chainTagLengthTranslators(merchantAccountInformation, unreservedTemplates).Translate([]rune(id), nil)
+	t := reflect.TypeOf(Code{})
+	for i := 0; i < t.NumField(); i++ {
+		if v, ok := t.Field(i).Tag.Lookup(tagName); ok && v == string(tag) {
+			return t.Field(i).Name
 		}
 	}
-	return nil
+	return ""
 }
@@ -1,7 +1,11 @@
 package mpm_test
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"go.mercari.io/go-emv-code/mpm"
@@ -47,12 +51,17 @@ func TestDecoder_Decode(t *testing.T) {
 					String: "23.72",
 					Valid:  true,
 				},
-				TipOrConvenienceIndicator:   mpm.TipOrConvenienceIndicatorPrompt,
-				CountryCode:                 "CN",
-				MerchantName:                "BEST TRANSPORT",
-				MerchantCity:                "BEIJING",
-				PostalCode:                  "",
-				AdditionalDataFieldTemplate: "030412340603***0708A60086670902ME",
+				TipOrConvenienceIndicator: mpm.TipOrConvenienceIndicatorPrompt,
+				CountryCode:               "CN",
+				MerchantName:              "BEST TRANSPORT",
+				MerchantCity:              "BEIJING",
+				PostalCode:                "",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					CustomerLabel:                 "***",
+					TerminalLabel:                 "A6008667",
+					AdditionalConsumerDataRequest: "ME",
+				},
 				MerchantInformation: mpm.NullMerchantInformation{
 					LanguagePreference: "ZH",
 					Name:               "最佳运输",
@@ -83,12 +92,17 @@ func TestDecoder_Decode(t *testing.T) {
 					String: "23.72",
 					Valid:  true,
 				},
-				TipOrConvenienceIndicator:   mpm.TipOrConvenienceIndicatorPrompt,
-				CountryCode:                 "CN",
-				MerchantName:                "BEST TRANSPORT",
-				MerchantCity:                "BEIJING",
-				PostalCode:                  "",
-				AdditionalDataFieldTemplate: "030412340603***0708A60086670902ME",
+				TipOrConvenienceIndicator: mpm.TipOrConvenienceIndicatorPrompt,
+				CountryCode:               "CN",
+				MerchantName:              "BEST TRANSPORT",
+				MerchantCity:              "BEIJING",
+				PostalCode:                "",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					CustomerLabel:                 "***",
+					TerminalLabel:                 "A6008667",
+					AdditionalConsumerDataRequest: "ME",
+				},
 				UnreservedTemplates: []tlv.TLV{
 					{Tag: "80", Length: "36", Value: "003239401ff0c21a4543a8ed5fbaa30ab02e"},
 					{Tag: "81", Length: "36", Value: "0032c2fbf6dd646f4f36b617f10747c0b961"},
@@ -113,12 +127,17 @@ func TestDecoder_Decode(t *testing.T) {
 					String: "23.72",
 					Valid:  true,
 				},
-				TipOrConvenienceIndicator:   mpm.TipOrConvenienceIndicatorPrompt,
-				CountryCode:                 "CN",
-				MerchantName:                "BEST TRANSPORT",
-				MerchantCity:                "BEIJING",
-				PostalCode:                  "",
-				AdditionalDataFieldTemplate: "030412340603***0708A60086670902ME",
+				TipOrConvenienceIndicator: mpm.TipOrConvenienceIndicatorPrompt,
+				CountryCode:               "CN",
+				MerchantName:              "BEST TRANSPORT",
+				MerchantCity:              "BEIJING",
+				PostalCode:                "",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					CustomerLabel:                 "***",
+					TerminalLabel:                 "A6008667",
+					AdditionalConsumerDataRequest: "ME",
+				},
 				MerchantInformation: mpm.NullMerchantInformation{
 					LanguagePreference: "ZH",
 					Name:               "最佳运输",
@@ -257,12 +276,17 @@ func TestEncoder_Encode(t *testing.T) {
 						String: "23.72",
 						Valid:  true,
 					},
-					TipOrConvenienceIndicator:   mpm.TipOrConvenienceIndicatorPrompt,
-					CountryCode:                 "CN",
-					MerchantName:                "BEST TRANSPORT",
-					MerchantCity:                "BEIJING",
-					PostalCode:                  "",
-					AdditionalDataFieldTemplate: "030412340603***0708A60086670902ME",
+					TipOrConvenienceIndicator: mpm.TipOrConvenienceIndicatorPrompt,
+					CountryCode:               "CN",
+					MerchantName:              "BEST TRANSPORT",
+					MerchantCity:              "BEIJING",
+					PostalCode:                "",
+					AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+						StoreLabel:                    "1234",
+						CustomerLabel:                 "***",
+						TerminalLabel:                 "A6008667",
+						AdditionalConsumerDataRequest: "ME",
+					},
 					MerchantInformation: mpm.NullMerchantInformation{
 						LanguagePreference: "ZH",
 						Name:               "最佳运输",
@@ -293,12 +317,17 @@ func TestEncoder_Encode(t *testing.T) {
 						String: "23.72",
 						Valid:  true,
 					},
-					TipOrConvenienceIndicator:   mpm.TipOrConvenienceIndicatorPrompt,
-					CountryCode:                 "CN",
-					MerchantName:                "BEST TRANSPORT",
-					MerchantCity:                "BEIJING",
-					PostalCode:                  "",
-					AdditionalDataFieldTemplate: "030412340603***0708A60086670902ME",
+					TipOrConvenienceIndicator: mpm.TipOrConvenienceIndicatorPrompt,
+					CountryCode:               "CN",
+					MerchantName:              "BEST TRANSPORT",
+					MerchantCity:              "BEIJING",
+					PostalCode:                "",
+					AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+						StoreLabel:                    "1234",
+						CustomerLabel:                 "***",
+						TerminalLabel:                 "A6008667",
+						AdditionalConsumerDataRequest: "ME",
+					},
 					UnreservedTemplates: []tlv.TLV{
 						{Tag: "80", Length: "36", Value: "003239401ff0c21a4543a8ed5fbaa30ab02e"},
 						{Tag: "81", Length: "36", Value: "0032c2fbf6dd646f4f36b617f10747c0b961"},
@@ -323,12 +352,17 @@ func TestEncoder_Encode(t *testing.T) {
 						String: "23.72",
 						Valid:  true,
 					},
-					TipOrConvenienceIndicator:   mpm.TipOrConvenienceIndicatorPrompt,
-					CountryCode:                 "CN",
-					MerchantName:                "BEST TRANSPORT",
-					MerchantCity:                "BEIJING",
-					PostalCode:                  "",
-					AdditionalDataFieldTemplate: "030412340603***0708A60086670902ME",
+					TipOrConvenienceIndicator: mpm.TipOrConvenienceIndicatorPrompt,
+					CountryCode:               "CN",
+					MerchantName:              "BEST TRANSPORT",
+					MerchantCity:              "BEIJING",
+					PostalCode:                "",
+					AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+						StoreLabel:                    "1234",
+						CustomerLabel:                 "***",
+						TerminalLabel:                 "A6008667",
+						AdditionalConsumerDataRequest: "ME",
+					},
 					MerchantInformation: mpm.NullMerchantInformation{
 						LanguagePreference: "ZH",
 						Name:               "最佳运输",
@@ -362,12 +396,17 @@ func TestEncoder_Encode(t *testing.T) {
 						String: "23.72",
 						Valid:  true,
 					},
-					TipOrConvenienceIndicator:   mpm.TipOrConvenienceIndicatorPrompt,
-					CountryCode:                 "CN",
-					MerchantName:                "BEST TRANSPORT",
-					MerchantCity:                "BEIJING",
-					PostalCode:                  "",
-					AdditionalDataFieldTemplate: "030412340603***0708A60086670902ME",
+					TipOrConvenienceIndicator: mpm.TipOrConvenienceIndicatorPrompt,
+					CountryCode:               "CN",
+					MerchantName:              "BEST TRANSPORT",
+					MerchantCity:              "BEIJING",
+					PostalCode:                "",
+					AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+						StoreLabel:                    "1234",
+						CustomerLabel:                 "***",
+						TerminalLabel:                 "A6008667",
+						AdditionalConsumerDataRequest: "ME",
+					},
 					MerchantInformation: mpm.NullMerchantInformation{
 						LanguagePreference: "ZH",
 						Name:               "最佳运输",
@@ -385,6 +424,53 @@ func TestEncoder_Encode(t *testing.T) {
 				return ok && e.InvalidFormat()
 			},
 		},
+		{
+			name: "err: BillNumber of AdditionalDataFieldTemplate is greater than 25",
+			args: args{
+				in: &mpm.Code{
+					PayloadFormatIndicator:  "01",
+					PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+					MerchantAccountInformation: []tlv.TLV{
+						{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+					},
+					MerchantCategoryCode: "4111",
+					TransactionCurrency:  "156",
+					CountryCode:          "CN",
+					MerchantName:         "BEST TRANSPORT",
+					MerchantCity:         "BEIJING",
+					AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+						BillNumber: "12345678901234567890123456",
+					},
+				},
+			},
+			wantErr: true,
+			wantErrTypeFunc: func(err error) bool {
+				var e *mpm.FieldError
+				return errors.As(err, &e) && e.ID == "62.01" && e.Reason == mpm.ReasonLength
+			},
+		},
+		{
+			name: "err: Length of MerchantAccountInformation does not match Value",
+			args: args{
+				in: &mpm.Code{
+					PayloadFormatIndicator:  "01",
+					PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+					MerchantAccountInformation: []tlv.TLV{
+						{Tag: "29", Length: "31", Value: "0012D156000000000510A93FO3230Q"},
+					},
+					MerchantCategoryCode: "4111",
+					TransactionCurrency:  "156",
+					CountryCode:          "CN",
+					MerchantName:         "BEST TRANSPORT",
+					MerchantCity:         "BEIJING",
+				},
+			},
+			wantErr: true,
+			wantErrTypeFunc: func(err error) bool {
+				var e *tlv.LengthMismatchError
+				return errors.As(err, &e) && e.Tag == "29"
+			},
+		},
 		{
 			name:    "err: cannot pass nil pointer",
 			wantErr: true,
@@ -414,15 +500,23 @@ func TestEncoder_Encode(t *testing.T) {
 
 func BenchmarkEncode(b *testing.B) {
 	code := &mpm.Code{
-		PayloadFormatIndicator:      "01",
-		PointOfInitiationMethod:     mpm.PointOfInitiationMethodDynamic,
-		MerchantCategoryCode:        "4111",
-		TransactionCurrency:         "156",
-		CountryCode:                 "CN",
-		MerchantName:                "BEST TRANSPORT",
-		MerchantCity:                "BEIJING",
-		PostalCode:                  "",
-		AdditionalDataFieldTemplate: "030412340603***0708A60086670902ME",
+		PayloadFormatIndicator:  "01",
+		PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+		MerchantAccountInformation: []tlv.TLV{
+			{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+		},
+		MerchantCategoryCode: "4111",
+		TransactionCurrency:  "156",
+		CountryCode:          "CN",
+		MerchantName:         "BEST TRANSPORT",
+		MerchantCity:         "BEIJING",
+		PostalCode:           "",
+		AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+			StoreLabel:                    "1234",
+			CustomerLabel:                 "***",
+			TerminalLabel:                 "A6008667",
+			AdditionalConsumerDataRequest: "ME",
+		},
 		MerchantInformation: mpm.NullMerchantInformation{
 			LanguagePreference: "ZH",
 			Name:               "最佳运输",
@@ -438,3 +532,329 @@ func BenchmarkEncode(b *testing.B) {
 		}
 	}
 }
+
+func TestDecoder_CollectAllErrors(t *testing.T) {
+	payload := []byte("00020101021329300012D156000000000510A93FO3230Q5204411153031565802CN5926ABCDEFGHIJKLMNOPQRSTUVWXYZ6007BEIJING64120001Z0103abc63043024")
+
+	if _, err := mpm.Decode(payload); err == nil {
+		t.Fatal("Decode() should return error")
+	} else if _, ok := err.(mpm.Errors); ok {
+		t.Errorf("Decode() should return the first error only, got %v", err)
+	}
+
+	_, err := (&mpm.Decoder{CollectAllErrors: true}).Decode(payload)
+
+	errs, ok := err.(mpm.Errors)
+	if !ok {
+		t.Fatalf("Decoder.Decode() error = %v, want mpm.Errors", err)
+	}
+	want := []string{"01", "59", "64.00"}
+	if len(errs) != len(want) {
+		t.Fatalf("Decoder.Decode() returns %d errors, want %d: %v", len(errs), len(want), err)
+	}
+	for i, e := range errs {
+		var fe *mpm.FieldError
+		if !errors.As(e, &fe) {
+			t.Errorf("errs[%d] = %v, want *mpm.FieldError", i, e)
+			continue
+		}
+		if fe.ID != want[i] {
+			t.Errorf("errs[%d].ID = %s, want %s", i, fe.ID, want[i])
+		}
+	}
+	if !errors.Is(err, mpm.InvalidFormat) {
+		t.Errorf("errors.Is(err, mpm.InvalidFormat) should be true")
+	}
+}
+
+func TestEncoder_CollectAllErrors(t *testing.T) {
+	c := &mpm.Code{
+		PayloadFormatIndicator:  "01",
+		PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+		MerchantAccountInformation: []tlv.TLV{
+			{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+		},
+		MerchantCategoryCode: "4111",
+		TransactionCurrency:  "156",
+		CountryCode:          "CN",
+		MerchantName:         "BEST TRANSPORT",
+		MerchantCity:         "BEIJING",
+		AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+			BillNumber:    "12345678901234567890123456",
+			TerminalLabel: "12345678901234567890123456",
+		},
+		MerchantInformation: mpm.NullMerchantInformation{
+			LanguagePreference: "ZH",
+			Valid:              true,
+		},
+	}
+
+	_, err := (&mpm.Encoder{CollectAllErrors: true}).Encode(c)
+
+	var fields []string
+	for _, e := range err.(mpm.Errors) {
+		fields = append(fields, e.(*mpm.FieldError).Field)
+	}
+	want := []string{
+		"AdditionalDataFieldTemplate.BillNumber",
+		"AdditionalDataFieldTemplate.TerminalLabel",
+		"MerchantInformation.Name",
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("Encoder.Encode() errors = %v, want %v", fields, want)
+	}
+}
+
+func TestEncoder_LengthUnit(t *testing.T) {
+	c := &mpm.Code{
+		PayloadFormatIndicator:  "01",
+		PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+		MerchantAccountInformation: []tlv.TLV{
+			{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+		},
+		MerchantCategoryCode: "4111",
+		TransactionCurrency:  "392",
+		CountryCode:          "JP",
+		MerchantName:         "BEST TRANSPORT",
+		MerchantCity:         "TOKYO",
+		MerchantInformation: mpm.NullMerchantInformation{
+			LanguagePreference: "JA",
+			Name:               "最佳运输",
+			City:               "東京",
+			Valid:              true,
+		},
+	}
+	tests := []struct {
+		name    string
+		unit    tlv.LengthUnit
+		fields  map[string]tlv.LengthUnit
+		wantMI  string
+		wantErr bool
+	}{
+		{
+			name:   "runes",
+			unit:   tlv.LengthUnitRune,
+			wantMI: "64200002JA0104最佳运输0202東京",
+		},
+		{
+			name:   "bytes",
+			unit:   tlv.LengthUnitByte,
+			wantMI: "64320002JA0112最佳运输0206東京",
+		},
+		{
+			name:   "bytes of MerchantInformation",
+			fields: map[string]tlv.LengthUnit{"64": tlv.LengthUnitByte},
+			wantMI: "64320002JA0112最佳运输0206東京",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			buf, err := (&mpm.Encoder{LengthUnit: tt.unit, FieldLengthUnits: tt.fields}).Encode(c)
+			if err != nil {
+				t.Fatalf("Encoder.Encode() error = %v", err)
+			}
+			if !bytes.Contains(buf, []byte(tt.wantMI)) {
+				t.Errorf("Encoder.Encode() = %s, want to contain %s", buf, tt.wantMI)
+			}
+			got, err := (&mpm.Decoder{LengthUnit: tt.unit, FieldLengthUnits: tt.fields}).Decode(buf)
+			if err != nil {
+				t.Fatalf("Decoder.Decode() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, c) {
+				t.Errorf("Decoder.Decode() = %+v, want %+v", got, c)
+			}
+		})
+	}
+}
+
+func TestEncoder_MaxSize(t *testing.T) {
+	c := &mpm.Code{
+		PayloadFormatIndicator:  "01",
+		PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+		MerchantAccountInformation: []tlv.TLV{
+			{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+		},
+		MerchantCategoryCode: "4111",
+		TransactionCurrency:  "392",
+		CountryCode:          "JP",
+		MerchantName:         "BEST TRANSPORT",
+		MerchantCity:         "TOKYO",
+	}
+	// 493 characters in 519 bytes.
+	for i := 0; i < 9; i++ {
+		c.UnreservedTemplates = append(c.UnreservedTemplates, tlv.TLV{Tag: fmt.Sprintf("%02d", 80+i), Length: "36", Value: "0032c2fbf6dd646f4f36b617f10747c0b961"})
+	}
+	c.MerchantInformation = mpm.NullMerchantInformation{
+		LanguagePreference: "JA",
+		Name:               strings.Repeat("あ", 8),
+		City:               strings.Repeat("い", 5),
+		Valid:              true,
+	}
+
+	if _, err := mpm.Encode(c); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if _, err := (&mpm.Encoder{LengthUnit: tlv.LengthUnitByte}).Encode(c); !errors.Is(err, mpm.InvalidFormat) {
+		t.Errorf("Encoder.Encode() error = %v, want InvalidFormat", err)
+	}
+
+	fields := map[string]tlv.LengthUnit{"64": tlv.LengthUnitByte}
+	buf, err := (&mpm.Encoder{FieldLengthUnits: fields}).Encode(c)
+	if err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	if _, err := (&mpm.Decoder{FieldLengthUnits: fields}).Decode(buf); err != nil {
+		t.Errorf("Decoder.Decode() error = %v", err)
+	}
+	if _, err := (&mpm.Decoder{LengthUnit: tlv.LengthUnitByte}).Decode(buf); !errors.Is(err, mpm.InvalidFormat) {
+		t.Errorf("Decoder.Decode() error = %v, want InvalidFormat", err)
+	}
+}
+
+func TestEncoder_LengthUnit_conformance(t *testing.T) {
+	c := &mpm.Code{
+		PayloadFormatIndicator:  "01",
+		PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+		MerchantAccountInformation: []tlv.TLV{
+			{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+		},
+		MerchantCategoryCode: "4111",
+		TransactionCurrency:  "392",
+		CountryCode:          "JP",
+		MerchantName:         "BEST TRANSPORT",
+		MerchantCity:         "TOKYO",
+		MerchantInformation: mpm.NullMerchantInformation{
+			LanguagePreference: "JA",
+			Name:               "最佳运输最佳运输最佳运输",
+			City:               "東京",
+			Valid:              true,
+		},
+	}
+	tests := []struct {
+		name       string
+		unit       tlv.LengthUnit
+		fields     map[string]tlv.LengthUnit
+		wantErr    bool
+		wantID     string
+		wantReason mpm.Reason
+	}{
+		{
+			name: "runes",
+			unit: tlv.LengthUnitRune,
+		},
+		{
+			name:       "bytes",
+			unit:       tlv.LengthUnitByte,
+			wantErr:    true,
+			wantID:     "64.01",
+			wantReason: mpm.ReasonLength,
+		},
+		{
+			name:       "bytes of MerchantInformation",
+			fields:     map[string]tlv.LengthUnit{"64": tlv.LengthUnitByte},
+			wantErr:    true,
+			wantID:     "64.01",
+			wantReason: mpm.ReasonLength,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := (&mpm.Encoder{LengthUnit: tt.unit, FieldLengthUnits: tt.fields}).Encode(c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Encoder.Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			var fe *mpm.FieldError
+			if !errors.As(err, &fe) || fe.ID != tt.wantID || fe.Reason != tt.wantReason {
+				t.Errorf("Encoder.Encode() error = %#v, want ID %s of %s", err, tt.wantID, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestDecode_RFUForEMVCo(t *testing.T) {
+	payload := []byte("00020101021229300012D156000000000510A93FO3230Q5204411153031565802CN5914BEST TRANSPORT6007BEIJING64280002ZH0104最佳运输0202北京0304TEST6504abcd7902xy8036003239401ff0c21a4543a8ed5fbaa30ab02eAB04test6304CF2E")
+
+	c, err := mpm.Decode(payload)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if want := []tlv.TLV{{Tag: "65", Length: "04", Value: "abcd"}, {Tag: "79", Length: "02", Value: "xy"}}; !reflect.DeepEqual(c.RFUForEMVCo, want) {
+		t.Errorf("Decode() RFUForEMVCo = %v, want %v", c.RFUForEMVCo, want)
+	}
+	if want := []tlv.TLV{{Tag: "03", Length: "04", Value: "TEST"}}; !reflect.DeepEqual(c.MerchantInformation.RFUForEMVCo, want) {
+		t.Errorf("Decode() MerchantInformation.RFUForEMVCo = %v, want %v", c.MerchantInformation.RFUForEMVCo, want)
+	}
+	if want := []tlv.TLV{{Tag: "AB", Length: "04", Value: "test"}}; !reflect.DeepEqual(c.Others, want) {
+		t.Errorf("Decode() Others = %v, want %v", c.Others, want)
+	}
+
+	buf, err := mpm.Encode(c)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !bytes.Equal(buf, payload) {
+		t.Errorf("Encode() = %s, want %s", buf, payload)
+	}
+}
+
+func TestDecode_AdditionalDataFieldTemplate_negativeLength(t *testing.T) {
+	payload := []byte("000201620801-1xxxx5802CN5901X6001Y63045E93")
+
+	for _, d := range []*mpm.Decoder{{}, {CollectAllErrors: true}} {
+		_, err := d.Decode(payload)
+		var fe *mpm.FieldError
+		if !errors.As(err, &fe) || fe.ID != "62" || fe.Reason != mpm.ReasonValue {
+			t.Errorf("Decoder.Decode() error = %#v, want ID 62 of %s", err, mpm.ReasonValue)
+		}
+	}
+}
+
+func TestDecoder_CollectAllErrors_garbage(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+	}{
+		{name: "negative length with invalid CRC", payload: "00020100-163040000"},
+		{name: "signed length with invalid CRC", payload: "00020159+1X63040000"},
+		{name: "negative nested length with invalid CRC", payload: "000201620801-1xxxx5802CN5901X6001Y63040000"},
+		{name: "truncated data object with invalid CRC", payload: "0002015910X63040000"},
+		{name: "non numeric data with invalid CRC", payload: "000201zzzzzzzzzz63040000"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := (&mpm.Decoder{CollectAllErrors: true}).Decode([]byte(tt.payload))
+			if !errors.Is(err, mpm.InvalidCRC) || !errors.Is(err, mpm.InvalidFormat) {
+				t.Errorf("Decoder.Decode() error = %v, want InvalidCRC and InvalidFormat", err)
+			}
+		})
+	}
+}
+
+func FuzzDecoder_Decode(f *testing.F) {
+	for _, s := range []string{
+		"00020101021229300012D156000000000510A93FO3230Q5204411153031565802CN5914BEST TRANSPORT6007BEIJING6304A13A",
+		"00020100-163040000",
+		"00020159-1X63041E29",
+		"000201620801-1xxxx5802CN5901X6001Y63045E93",
+		"0002016304",
+		"garbage",
+	} {
+		f.Add([]byte(s))
+	}
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		for _, d := range []*mpm.Decoder{{}, {CollectAllErrors: true}, {CollectAllErrors: true, AllowDuplicateTags: true, PreserveOrder: true, LengthUnit: tlv.LengthUnitByte}} {
+			c, err := d.Decode(payload)
+			if err == nil {
+				if _, err := (&mpm.Encoder{SkipConformance: true, LengthUnit: d.LengthUnit}).Encode(c); err != nil {
+					t.Errorf("Encoder.Encode() of the decoded Code error = %v", err)
+				}
+			}
+		}
+	})
+}
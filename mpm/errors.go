@@ -1,6 +1,9 @@
 package mpm
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 type errorCode int
 
@@ -11,6 +14,18 @@ const (
 	InvalidCRC
 )
 
+// Error returns a description of c.
+// errorCode implements error so that errors.Is(err, InvalidFormat) or errors.Is(err, InvalidCRC) can be used.
+func (c errorCode) Error() string {
+	switch c {
+	case InvalidFormat:
+		return "mpm: invalid format"
+	case InvalidCRC:
+		return "mpm: invalid CRC"
+	}
+	return fmt.Sprintf("mpm: unknown error code %d", int(c))
+}
+
 type genericError struct {
 	code errorCode
 	msg  string
@@ -20,6 +35,12 @@ func (e *genericError) Error() string {
 	return e.msg
 }
 
+// Is returns true if target is the errorCode of e.
+func (e *genericError) Is(target error) bool {
+	c, ok := target.(errorCode)
+	return ok && c == e.code
+}
+
 // InvalidFormat returns true if code is InvalidFormat.
 func (e *genericError) InvalidFormat() bool {
 	return e.code == InvalidFormat
@@ -45,3 +66,88 @@ func NewInvalidCRC(expected, got uint16) error {
 		msg:  fmt.Sprintf("mpm: expected CRC is %x not %x", expected, got),
 	}
 }
+
+// Reason represents a machine-readable reason why a data object is invalid.
+type Reason string
+
+const (
+	// ReasonMissing represents a mandatory data object is missing.
+	ReasonMissing Reason = "missing"
+	// ReasonLength represents the length of a data object is out of range.
+	ReasonLength Reason = "length"
+	// ReasonValue represents the value of a data object is not acceptable.
+	ReasonValue Reason = "value"
+	// ReasonUnexpected represents a data object is present where it should not be.
+	ReasonUnexpected Reason = "unexpected"
+)
+
+// FieldError represents a data object which has invalid format.
+// It is also InvalidFormat.
+type FieldError struct {
+	// ID is the EMV ID of the data object. IDs of nested data objects are joined by ".", e.g. "64.01".
+	ID string
+	// Field is the path of the field in Code, e.g. "MerchantInformation.Name".
+	Field string
+	// Value is the offending value.
+	Value string
+	// Offset is the byte offset of the data object in the payload, or -1 if unknown.
+	Offset int
+	// Reason is the machine-readable reason.
+	Reason Reason
+
+	msg   string
+	cause error
+}
+
+func (e *FieldError) Error() string {
+	return e.msg
+}
+
+// Unwrap returns the underlying error, e.g. *tlv.DuplicateTagError, if any.
+func (e *FieldError) Unwrap() error {
+	return e.cause
+}
+
+// Is returns true if target is InvalidFormat.
+func (e *FieldError) Is(target error) bool {
+	c, ok := target.(errorCode)
+	return ok && c == InvalidFormat
+}
+
+// InvalidFormat always returns true.
+func (e *FieldError) InvalidFormat() bool {
+	return true
+}
+
+// NewFieldError creates a new FieldError. Offset is filled by Decode.
+func NewFieldError(id, field, value string, reason Reason, msg string) error {
+	return &FieldError{
+		ID:     id,
+		Field:  field,
+		Value:  value,
+		Offset: -1,
+		Reason: reason,
+		msg:    msg,
+	}
+}
+
+// Errors represents multiple problems in the order of occurrence.
+// It is returned when Decoder.CollectAllErrors or Encoder.CollectAllErrors is set.
+type Errors []error
+
+func (e Errors) Error() string {
+	return errors.Join(e...).Error()
+}
+
+// Unwrap returns the errors to be inspected by errors.Is and errors.As.
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+// err returns nil if e is empty, otherwise e.
+func (e Errors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
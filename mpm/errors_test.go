@@ -1,10 +1,12 @@
 package mpm_test
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
 	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/tlv"
 )
 
 func TestNewInvalidFormat(t *testing.T) {
@@ -49,3 +51,212 @@ func TestNewInvalidCRC(t *testing.T) {
 		t.Errorf("unexpexted value expected: %t, give: %t", true, i.InvalidCRC())
 	}
 }
+
+func TestErrorsIs(t *testing.T) {
+	tests := []struct {
+		name              string
+		give              error
+		wantInvalidFormat bool
+		wantInvalidCRC    bool
+	}{
+		{
+			name:              "give InvalidFormat",
+			give:              mpm.NewInvalidFormat("testing"),
+			wantInvalidFormat: true,
+		},
+		{
+			name:           "give InvalidCRC",
+			give:           mpm.NewInvalidCRC(1, 2),
+			wantInvalidCRC: true,
+		},
+		{
+			name:              "give FieldError",
+			give:              mpm.NewFieldError("59", "MerchantName", "", mpm.ReasonMissing, "testing"),
+			wantInvalidFormat: true,
+		},
+		{
+			name:              "give wrapped FieldError",
+			give:              fmt.Errorf("wrapped: %w", mpm.NewFieldError("59", "MerchantName", "", mpm.ReasonMissing, "testing")),
+			wantInvalidFormat: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.give, mpm.InvalidFormat); got != tt.wantInvalidFormat {
+				t.Errorf("errors.Is(err, mpm.InvalidFormat) = %t, want %t", got, tt.wantInvalidFormat)
+			}
+			if got := errors.Is(tt.give, mpm.InvalidCRC); got != tt.wantInvalidCRC {
+				t.Errorf("errors.Is(err, mpm.InvalidCRC) = %t, want %t", got, tt.wantInvalidCRC)
+			}
+		})
+	}
+}
+
+func TestDecode_FieldError(t *testing.T) {
+	tests := []struct {
+		name string
+		give []byte
+		want mpm.FieldError
+	}{
+		{
+			name: "give invalid PointOfInitiationMethod",
+			give: []byte("0002010102135204411153031565802CN5914BEST TRANSPORT6007BEIJING63043086"),
+			want: mpm.FieldError{
+				ID:     "01",
+				Field:  "PointOfInitiationMethod",
+				Value:  "13",
+				Offset: 6,
+				Reason: mpm.ReasonValue,
+			},
+		},
+		{
+			name: "give too long MerchantInformation.Name",
+			give: []byte("00020101021129300012D156000000000510A93FO3230Q5204411153031565802CN5914BEST TRANSPORT6007BEIJING64360002ZH0126最佳运输最佳运输最佳运输最佳运输最佳运输最佳运输最佳6304F492"),
+			want: mpm.FieldError{
+				ID:     "64.01",
+				Field:  "MerchantInformation.Name",
+				Value:  "最佳运输最佳运输最佳运输最佳运输最佳运输最佳运输最佳",
+				Offset: 106,
+				Reason: mpm.ReasonLength,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mpm.Decode(tt.give)
+
+			var e *mpm.FieldError
+			if !errors.As(err, &e) {
+				t.Fatalf("Decode() error = %v, want *mpm.FieldError", err)
+			}
+			if e.ID != tt.want.ID || e.Field != tt.want.Field || e.Value != tt.want.Value || e.Offset != tt.want.Offset || e.Reason != tt.want.Reason {
+				t.Errorf("Decode() error = %+v, want %+v", e, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode_DuplicateTag(t *testing.T) {
+	tests := []struct {
+		name            string
+		payload         string
+		wantErr         bool
+		wantID          string
+		wantOffset      int
+		wantFirstOffset int
+	}{
+		{
+			name:            "err: duplicate MerchantName",
+			payload:         "00020101021229300012D156000000000510A93FO3230Q5204411153031565802CN5914BEST TRANSPORT6007BEIJING5904EVIL63048327",
+			wantErr:         true,
+			wantID:          "59",
+			wantOffset:      96,
+			wantFirstOffset: 67,
+		},
+		{
+			name:            "err: duplicate StoreLabel",
+			payload:         "00020101021229300012D156000000000510A93FO3230Q5204411153031565802CN5914BEST TRANSPORT6007BEIJING621603041234030456780902ME63045F3A",
+			wantErr:         true,
+			wantID:          "62.03",
+			wantOffset:      108,
+			wantFirstOffset: 100,
+		},
+		{
+			name:    "pass: repeated MerchantInformation",
+			payload: "00020101021229300012D156000000000510A93FO3230Q5204411153031565802CN5914BEST TRANSPORT6007BEIJING64200002ZH0104最佳运输0202北京64200002JA0104最佳運輸0202北京6304C93D",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mpm.Decode([]byte(tt.payload))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			var fe *mpm.FieldError
+			if !errors.As(err, &fe) || fe.ID != tt.wantID || fe.Offset != tt.wantOffset || fe.Reason != mpm.ReasonUnexpected {
+				t.Errorf("Decode() error = %#v, want ID %s at %d", err, tt.wantID, tt.wantOffset)
+			}
+			var de *tlv.DuplicateTagError
+			if !errors.As(err, &de) || de.Offset != tt.wantFirstOffset || de.DuplicateOffset != tt.wantOffset {
+				t.Errorf("Decode() error = %v, want DuplicateTagError at %d and %d", err, tt.wantFirstOffset, tt.wantOffset)
+			}
+			if !errors.Is(err, mpm.InvalidFormat) {
+				t.Errorf("Decode() error = %v, want InvalidFormat", err)
+			}
+
+			if _, err := (&mpm.Decoder{AllowDuplicateTags: true}).Decode([]byte(tt.payload)); err != nil {
+				t.Errorf("Decoder.Decode() with AllowDuplicateTags error = %v", err)
+			}
+		})
+	}
+}
+
+func TestDecode_Structure(t *testing.T) {
+	tests := []struct {
+		name       string
+		payload    string
+		wantID     string
+		wantOffset int
+	}{
+		{
+			name:       "err: CRC before the last data object",
+			payload:    "00020101021229300012D156000000000510A93FO3230Q5204411153031565802CN5914BEST TRANSPORT6007BEIJING6304ABCD6304F97A",
+			wantID:     "63",
+			wantOffset: 96,
+		},
+		{
+			name:       "err: PayloadFormatIndicator after the first data object",
+			payload:    "00020101021229300012D156000000000510A93FO3230Q5204411153031565802CN5914BEST TRANSPORT6007BEIJING0002016304D2A1",
+			wantID:     "00",
+			wantOffset: 96,
+		},
+		{
+			name:    "err: trailing data after CRC",
+			payload: "00020101021229300012D156000000000510A93FO3230Q5204411153031565802CN5914BEST TRANSPORT6007BEIJING6304ABCDxx6304B0EB",
+		},
+		{
+			name:    "err: payload exceeds MaxSize",
+			payload: "00020101021229300012D156000000000510A93FO3230Q5204411153031565802CN5914BEST TRANSPORT6007BEIJING80360032c2fbf6dd646f4f36b617f10747c0b96181360032c2fbf6dd646f4f36b617f10747c0b96182360032c2fbf6dd646f4f36b617f10747c0b96183360032c2fbf6dd646f4f36b617f10747c0b96184360032c2fbf6dd646f4f36b617f10747c0b96185360032c2fbf6dd646f4f36b617f10747c0b96186360032c2fbf6dd646f4f36b617f10747c0b96187360032c2fbf6dd646f4f36b617f10747c0b96188360032c2fbf6dd646f4f36b617f10747c0b96189360032c2fbf6dd646f4f36b617f10747c0b96190100006abcdef63049E4F",
+		},
+		{
+			name:    "err: negative length",
+			payload: "00020159-1X63041E29",
+		},
+		{
+			name:    "err: signed length",
+			payload: "00020159+1X6304BF0C",
+		},
+		{
+			name:    "err: length with a space",
+			payload: "00020159 1X6304F423",
+		},
+		{
+			name:    "err: truncated data object",
+			payload: "00020101021229300012D156000000000510A93FO3230Q5204411153031565802CN5914BEST TRANSPORT6007BEIJING58026304E324",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			for _, d := range []*mpm.Decoder{{}, {AllowDuplicateTags: true, CollectAllErrors: true}} {
+				_, err := d.Decode([]byte(tt.payload))
+				if !errors.Is(err, mpm.InvalidFormat) {
+					t.Fatalf("Decoder.Decode() error = %v, want InvalidFormat", err)
+				}
+				if tt.wantID == "" {
+					continue
+				}
+				var fe *mpm.FieldError
+				if !errors.As(err, &fe) || fe.ID != tt.wantID || fe.Offset != tt.wantOffset || fe.Reason != mpm.ReasonUnexpected {
+					t.Errorf("Decoder.Decode() error = %#v, want ID %s at %d", err, tt.wantID, tt.wantOffset)
+				}
+			}
+		})
+	}
+}
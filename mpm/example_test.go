@@ -10,15 +10,23 @@ import (
 
 func ExampleDecode() {
 	c := mpm.Code{
-		PayloadFormatIndicator:      "01",
-		PointOfInitiationMethod:     mpm.PointOfInitiationMethodDynamic,
-		MerchantCategoryCode:        "4111",
-		TransactionCurrency:         "156",
-		CountryCode:                 "CN",
-		MerchantName:                "BEST TRANSPORT",
-		MerchantCity:                "BEIJING",
-		PostalCode:                  "",
-		AdditionalDataFieldTemplate: "030412340603***0708A60086670902ME",
+		PayloadFormatIndicator:  "01",
+		PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+		MerchantAccountInformation: []tlv.TLV{
+			{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+		},
+		MerchantCategoryCode: "4111",
+		TransactionCurrency:  "156",
+		CountryCode:          "CN",
+		MerchantName:         "BEST TRANSPORT",
+		MerchantCity:         "BEIJING",
+		PostalCode:           "",
+		AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+			StoreLabel:                    "1234",
+			CustomerLabel:                 "***",
+			TerminalLabel:                 "A6008667",
+			AdditionalConsumerDataRequest: "ME",
+		},
 		UnreservedTemplates: []tlv.TLV{
 			{Tag: "80", Length: "36", Value: "003239401ff0c21a4543a8ed5fbaa30ab02e"},
 		},
@@ -34,8 +42,41 @@ func ExampleDecode() {
 		log.Fatal(err)
 	}
 
-	fmt.Printf("%+v\n", dst)
+	fmt.Println(dst.MerchantAccountInformation)
+	fmt.Println(dst.MerchantName, dst.MerchantCity)
+	fmt.Println(dst.AdditionalDataFieldTemplate.StoreLabel)
+	fmt.Println(dst.UnreservedTemplates)
+
+	// Output:
+	// [{29 30 0012D156000000000510A93FO3230Q}]
+	// BEST TRANSPORT BEIJING
+	// 1234
+	// [{80 36 003239401ff0c21a4543a8ed5fbaa30ab02e}]
+}
+
+func ExampleBuilder() {
+	c, err := mpm.NewBuilder().
+		PointOfInitiationMethod(mpm.PointOfInitiationMethodDynamic).
+		MerchantAccount("D15600000000", tlv.TLV{Tag: "05", Value: "A93FO3230Q"}).
+		MerchantCategoryCode("4111").
+		TransactionCurrency("156").
+		TransactionAmount("23.72").
+		CountryCode("CN").
+		MerchantName("BEST TRANSPORT").
+		MerchantCity("BEIJING").
+		UnreservedTemplate("39401ff0c21a4543a8ed5fbaa30ab02e").
+		Build()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	buf, err := mpm.Encode(c)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(string(buf))
 
 	// Output:
-	// &{PayloadFormatIndicator:01 PointOfInitiationMethod:12 MerchantAccountInformation:[] MerchantCategoryCode:4111 TransactionCurrency:156 TransactionAmount:{String: Valid:false} TipOrConvenienceIndicator: ValueOfConvenienceFeeFixed:{String: Valid:false} ValueOfConvenienceFeePercentage:{String: Valid:false} CountryCode:CN MerchantName:BEST TRANSPORT MerchantCity:BEIJING PostalCode: AdditionalDataFieldTemplate:030412340603***0708A60086670902ME MerchantInformation:{LanguagePreference: Name: City: Valid:false} UnreservedTemplates:[{Tag:80 Length:36 Value:003239401ff0c21a4543a8ed5fbaa30ab02e}]}
+	// 00020101021226300012D156000000000510A93FO3230Q520441115303156540523.725802CN5914BEST TRANSPORT6007BEIJING8036003239401ff0c21a4543a8ed5fbaa30ab02e630460C4
 }
@@ -0,0 +1,70 @@
+package mpm
+
+import (
+	"fmt"
+
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+// StaticDynamicValidators returns validators of the static and dynamic policy many national schemes require:
+// Point of Initiation Method should be present, a dynamic Code should carry Transaction Amount and
+// a static Code, which is reusable for many payments, should not.
+// They are not run by default; pass them to Decode, Encode or Builder.Build explicitly.
+// Without them, a Code of no Point of Initiation Method is encoded without ID 01.
+func StaticDynamicValidators() []ValidatorFunc {
+	return []ValidatorFunc{
+		validatePointOfInitiationMethodPresence,
+		validateStaticDynamicAmount,
+	}
+}
+
+// IsDynamic reports whether the Code is for a single payment, that is, Point of Initiation Method is 12.
+func (c *Code) IsDynamic() bool {
+	return c.PointOfInitiationMethod == PointOfInitiationMethodDynamic
+}
+
+// WithAmount returns a copy of the Code for a single payment of amount.
+// It sets Transaction Amount and switches Point of Initiation Method to dynamic.
+// The exponent of amount should match Transaction Currency if it is known.
+func (c *Code) WithAmount(amount Amount) (*Code, error) {
+	if !amount.Valid() {
+		return nil, fmt.Errorf("mpm: amount %s cannot be represented as TransactionAmount", amount)
+	}
+	if exponent, ok := CurrencyExponent(c.TransactionCurrency); ok && exponent != amount.Exponent {
+		return nil, fmt.Errorf("mpm: exponent of amount %s should be %d for TransactionCurrency %s", amount, exponent, c.TransactionCurrency)
+	}
+	d := *c
+	d.MerchantAccountInformation = append([]tlv.TLV(nil), c.MerchantAccountInformation...)
+	d.AdditionalDataFieldTemplate.RFUForEMVCo = append([]tlv.TLV(nil), c.AdditionalDataFieldTemplate.RFUForEMVCo...)
+	d.AdditionalDataFieldTemplate.PaymentSystemSpecificTemplates = append([]tlv.TLV(nil), c.AdditionalDataFieldTemplate.PaymentSystemSpecificTemplates...)
+	d.MerchantInformation.RFUForEMVCo = append([]tlv.TLV(nil), c.MerchantInformation.RFUForEMVCo...)
+	d.AlternateMerchantInformation = append(AlternateMerchantInformation(nil), c.AlternateMerchantInformation...)
+	for i, m := range c.AlternateMerchantInformation {
+		d.AlternateMerchantInformation[i].RFUForEMVCo = append([]tlv.TLV(nil), m.RFUForEMVCo...)
+	}
+	d.RFUForEMVCo = append([]tlv.TLV(nil), c.RFUForEMVCo...)
+	d.UnreservedTemplates = append([]tlv.TLV(nil), c.UnreservedTemplates...)
+	d.Others = append([]tlv.TLV(nil), c.Others...)
+	d.raw = append([]tlv.TLV(nil), c.raw...)
+
+	d.PointOfInitiationMethod = PointOfInitiationMethodDynamic
+	d.TransactionAmount = NullString{String: amount.String(), Valid: true}
+	return &d, nil
+}
+
+func validatePointOfInitiationMethodPresence(c *Code) error {
+	if c.PointOfInitiationMethod == "" {
+		return NewFieldError("01", "PointOfInitiationMethod", "", ReasonMissing, fmt.Sprintf("mpm: PointOfInitiationMethod should be %s or %s, not missing", PointOfInitiationMethodStatic, PointOfInitiationMethodDynamic))
+	}
+	return nil
+}
+
+func validateStaticDynamicAmount(c *Code) error {
+	switch {
+	case c.PointOfInitiationMethod == PointOfInitiationMethodDynamic && !c.TransactionAmount.Valid:
+		return NewFieldError("54", "TransactionAmount", "", ReasonMissing, fmt.Sprintf("mpm: TransactionAmount should be represented if PointOfInitiationMethod is %s", PointOfInitiationMethodDynamic))
+	case c.PointOfInitiationMethod == PointOfInitiationMethodStatic && c.TransactionAmount.Valid:
+		return NewFieldError("54", "TransactionAmount", c.TransactionAmount.String, ReasonUnexpected, fmt.Sprintf("mpm: TransactionAmount of a single payment should be represented with PointOfInitiationMethod %s, not %s", PointOfInitiationMethodDynamic, PointOfInitiationMethodStatic))
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package mpm_test
+
+import (
+	"errors"
+	"testing"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+func TestStaticDynamicValidators(t *testing.T) {
+	tests := []struct {
+		name       string
+		give       *mpm.Code
+		wantErr    bool
+		wantID     string
+		wantReason mpm.Reason
+	}{
+		{
+			name: "pass: dynamic with TransactionAmount",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+		},
+		{
+			name: "pass: static without TransactionAmount",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode: "4111",
+				TransactionCurrency:  "156",
+				CountryCode:          "CN",
+				MerchantName:         "BEST TRANSPORT",
+				MerchantCity:         "BEIJING",
+				PostalCode:           "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+		},
+		{
+			name: "err: PointOfInitiationMethod is missing",
+			give: &mpm.Code{
+				PayloadFormatIndicator: "01",
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "01",
+			wantReason: mpm.ReasonMissing,
+		},
+		{
+			name: "err: dynamic without TransactionAmount",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "54",
+			wantReason: mpm.ReasonMissing,
+		},
+		{
+			name: "err: static with TransactionAmount",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "54",
+			wantReason: mpm.ReasonUnexpected,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := mpm.Encode(tt.give); err != nil {
+				t.Fatalf("Encode() without StaticDynamicValidators error = %v", err)
+			}
+			_, err := mpm.Encode(tt.give, mpm.StaticDynamicValidators()...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			var fe *mpm.FieldError
+			if !errors.As(err, &fe) || fe.ID != tt.wantID || fe.Reason != tt.wantReason {
+				t.Errorf("Encode() error = %#v, want ID %s of %s", err, tt.wantID, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestCode_WithAmount(t *testing.T) {
+	c := &mpm.Code{
+		PayloadFormatIndicator:  "01",
+		PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+		MerchantAccountInformation: []tlv.TLV{
+			{Tag: "04", Length: "16", Value: "4000123456789012"},
+			{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+		},
+		MerchantCategoryCode: "4111",
+		TransactionCurrency:  "156",
+		CountryCode:          "CN",
+		MerchantName:         "BEST TRANSPORT",
+		MerchantCity:         "BEIJING",
+		PostalCode:           "100000",
+		AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+			StoreLabel:                    "1234",
+			AdditionalConsumerDataRequest: "ME",
+			RFUForEMVCo: []tlv.TLV{
+				{Tag: "10", Length: "04", Value: "TEST"},
+			},
+			PaymentSystemSpecificTemplates: []tlv.TLV{
+				{Tag: "50", Length: "16", Value: "0012D15600000000"},
+			},
+		},
+	}
+	if c.IsDynamic() {
+		t.Fatal("Code.IsDynamic() = true, want false")
+	}
+
+	got, err := c.WithAmount(mpm.Amount{MinorUnits: 2372, Exponent: 2})
+	if err != nil {
+		t.Fatalf("Code.WithAmount() error = %v", err)
+	}
+	if !got.IsDynamic() || got.TransactionAmount != (mpm.NullString{String: "23.72", Valid: true}) {
+		t.Errorf("Code.WithAmount() = %+v, want dynamic Code of 23.72", got)
+	}
+	if c.IsDynamic() || c.TransactionAmount.Valid {
+		t.Errorf("Code.WithAmount() modified the receiver: %+v", c)
+	}
+	got.AdditionalDataFieldTemplate.RFUForEMVCo[0].Value = "EDIT"
+	got.AdditionalDataFieldTemplate.PaymentSystemSpecificTemplates[0].Value = "0012D15600000001"
+	if c.AdditionalDataFieldTemplate.RFUForEMVCo[0].Value != "TEST" || c.AdditionalDataFieldTemplate.PaymentSystemSpecificTemplates[0].Value != "0012D15600000000" {
+		t.Errorf("Code.WithAmount() shares AdditionalDataFieldTemplate with the receiver: %+v", c.AdditionalDataFieldTemplate)
+	}
+	if _, err := mpm.Encode(got, mpm.StaticDynamicValidators()...); err != nil {
+		t.Errorf("Encode() error = %v", err)
+	}
+
+	if _, err := c.WithAmount(mpm.Amount{MinorUnits: 100, Exponent: 0}); err == nil {
+		t.Error("Code.WithAmount() of another exponent error = nil, want error")
+	}
+}
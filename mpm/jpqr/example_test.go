@@ -41,8 +41,15 @@ func ExampleDecode() {
 		log.Fatal(err)
 	}
 
-	fmt.Printf("%+v\n", dst)
+	id, err := jpqr.ParseID(dst)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%+v\n", *id)
+	fmt.Println(dst.MerchantInformation.LanguagePreference, dst.MerchantInformation.Name)
 
 	// Output:
-	// &{PayloadFormatIndicator:01 PointOfInitiationMethod:11 MerchantAccountInformation:[{Tag:29 Length:30 Value:0012D156000000000510A93FO3230Q} {Tag:31 Length:28 Value:0012D15600000001030812345678} {Tag:26 Length:68 Value:0019jp.or.paymentsjapan011300000000000010204000103060000010406000001}] MerchantCategoryCode:5812 TransactionCurrency:392 TransactionAmount:{String: Valid:false} TipOrConvenienceIndicator: ValueOfConvenienceFeeFixed:{String: Valid:false} ValueOfConvenienceFeePercentage:{String: Valid:false} CountryCode:JP MerchantName:xxx MerchantCity:xxx PostalCode:1066143 AdditionalDataFieldTemplate: MerchantInformation:{LanguagePreference:JA Name:メルペイ カフェ City: Valid:true} UnreservedTemplates:[]}
+	// {Prefix:jp.or.paymentsjapan LV1:0000000000001 LV2:0001 LV3:000001 LV4:000001}
+	// JA メルペイ カフェ
 }
@@ -57,21 +57,61 @@ func validateIDLength(i *ID) error {
 	return nil
 }
 
+func init() {
+	mpm.RegisterScheme(mpm.Scheme{
+		Name:  "JPQR",
+		Match: mpm.MatchGUID(idPrefix),
+		Parse: parseID,
+	})
+}
+
+func parseID(m *mpm.MerchantAccountInformationTemplate) (interface{}, error) {
+	id := ID{Prefix: m.GloballyUniqueIdentifier}
+	id.LV1, _ = m.Value("01")
+	id.LV2, _ = m.Value("02")
+	id.LV3, _ = m.Value("03")
+	id.LV4, _ = m.Value("04")
+	if err := validateIDLength(&id); err != nil {
+		v, _ := m.Tokenize()
+		return nil, mpm.NewFieldError(m.ID, "MerchantAccountInformation", v, mpm.ReasonLength, fmt.Sprintf("jpqr: %s", err))
+	}
+	return &id, nil
+}
+
+var errMissingID = errors.New("missing JPQR-ID")
+
 // ParseID validates and parses given *mpm.Code as JPQR-ID.
+// Every Merchant Account Information (ID 02–51) is searched for the GloballyUniqueIdentifier "jp.or.paymentsjapan",
+// which is compared case-sensitively unlike mpm.MatchGUID. Entries which are not templates are skipped; if no JPQR-ID is
+// found and a template (ID 26–51) is malformed, it returns *mpm.FieldError of the ID of the first malformed template.
+// If the JPQR-ID is malformed, it returns *mpm.FieldError of the ID of the template.
 func ParseID(c *mpm.Code) (*ID, error) {
-	for _, v := range c.MerchantAccountInformation {
-		var id ID
-		if err := tlv.NewDecoder(strings.NewReader(v.Value), "lv", mpm.MaxSize, 2, 2, nil).Decode(&id); err != nil {
-			return nil, err
-		}
-		if id.Prefix == idPrefix {
-			if err := validateIDLength(&id); err != nil {
-				return nil, err
+	var malformed error
+	for _, t := range c.MerchantAccountInformation {
+		m := mpm.MerchantAccountInformationTemplate{ID: t.Tag}
+		if err := m.Scan([]rune(t.Value)); err != nil {
+			if malformed == nil && isTemplateID(t.Tag) {
+				malformed = mpm.NewFieldError(t.Tag, "MerchantAccountInformation", t.Value, mpm.ReasonValue, fmt.Sprintf("jpqr: failed to parse MerchantAccountInformation %s: %s", t.Tag, err))
 			}
-			return &id, nil
+			continue
+		}
+		if m.GloballyUniqueIdentifier != idPrefix {
+			continue
+		}
+		v, err := parseID(&m)
+		if err != nil {
+			return nil, err
 		}
+		return v.(*ID), nil
 	}
-	return nil, errors.New("missing JPQR-ID")
+	if malformed != nil {
+		return nil, malformed
+	}
+	return nil, errMissingID
+}
+
+func isTemplateID(id string) bool {
+	return len(id) == 2 && "26" <= id && id <= "51"
 }
 
 // ParseIDFromString validates and parses given string as JPQR-ID.
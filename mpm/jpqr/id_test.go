@@ -1,6 +1,7 @@
 package jpqr_test
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -14,10 +15,12 @@ func TestParseID(t *testing.T) {
 		c *mpm.Code
 	}
 	tests := []struct {
-		name    string
-		args    args
-		want    *jpqr.ID
-		wantErr bool
+		name       string
+		args       args
+		want       *jpqr.ID
+		wantErr    bool
+		wantID     string
+		wantReason mpm.Reason
 	}{
 		{
 			args: args{
@@ -40,6 +43,42 @@ func TestParseID(t *testing.T) {
 					},
 				},
 			},
+			wantErr:    true,
+			wantID:     "26",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "JPQR-ID of primitive ID after a PAN",
+			args: args{
+				c: &mpm.Code{
+					MerchantAccountInformation: []tlv.TLV{
+						{Tag: "02", Length: "16", Value: "4111111111111111"},
+						{Tag: "03", Length: "68", Value: "0019jp.or.paymentsjapan011300000000000010204000103060000010406000001"},
+					},
+				},
+			},
+			want: &jpqr.ID{"jp.or.paymentsjapan", "0000000000001", "0001", "000001", "000001"},
+		},
+		{
+			name: "fail: missing with a PAN",
+			args: args{
+				c: &mpm.Code{
+					MerchantAccountInformation: []tlv.TLV{
+						{Tag: "02", Length: "16", Value: "4111111111111111"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail: prefix is case-sensitive",
+			args: args{
+				c: &mpm.Code{
+					MerchantAccountInformation: []tlv.TLV{
+						{Tag: "26", Length: "68", Value: "0019JP.OR.PAYMENTSJAPAN011300000000000010204000103060000010406000001"},
+					},
+				},
+			},
 			wantErr: true,
 		},
 		{
@@ -62,7 +101,9 @@ func TestParseID(t *testing.T) {
 					},
 				},
 			},
-			wantErr: true,
+			wantErr:    true,
+			wantID:     "28",
+			wantReason: mpm.ReasonLength,
 		},
 		{
 			name: "fail: invalid length (LV2)",
@@ -73,7 +114,9 @@ func TestParseID(t *testing.T) {
 					},
 				},
 			},
-			wantErr: true,
+			wantErr:    true,
+			wantID:     "29",
+			wantReason: mpm.ReasonLength,
 		},
 		{
 			name: "fail: invalid length (LV3)",
@@ -84,7 +127,9 @@ func TestParseID(t *testing.T) {
 					},
 				},
 			},
-			wantErr: true,
+			wantErr:    true,
+			wantID:     "30",
+			wantReason: mpm.ReasonLength,
 		},
 		{
 			name: "fail: invalid length (LV4)",
@@ -95,7 +140,9 @@ func TestParseID(t *testing.T) {
 					},
 				},
 			},
-			wantErr: true,
+			wantErr:    true,
+			wantID:     "31",
+			wantReason: mpm.ReasonLength,
 		},
 	}
 	for _, tt := range tests {
@@ -108,6 +155,13 @@ func TestParseID(t *testing.T) {
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ParseID() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if tt.wantReason == "" {
+				return
+			}
+			var e *mpm.FieldError
+			if !errors.As(err, &e) || e.ID != tt.wantID || e.Reason != tt.wantReason {
+				t.Errorf("ParseID() error = %#v, want ID %s and Reason %s", err, tt.wantID, tt.wantReason)
+			}
 		})
 	}
 }
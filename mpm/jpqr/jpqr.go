@@ -4,6 +4,7 @@ Package jpqr implements encoding and decoding of JPQR as defined in JPQR MPM Gui
 package jpqr
 
 import (
+	"errors"
 	"fmt"
 
 	"go.mercari.io/go-emv-code/mpm"
@@ -36,10 +37,11 @@ func Encode(c *mpm.Code) ([]byte, error) {
 }
 
 func validateID(c *mpm.Code) error {
-	if _, err := ParseID(c); err != nil {
-		return mpm.NewInvalidFormat(fmt.Sprintf("jpqr: %s", err))
+	_, err := ParseID(c)
+	if errors.Is(err, errMissingID) {
+		return mpm.NewFieldError("", "MerchantAccountInformation", "", mpm.ReasonMissing, fmt.Sprintf("jpqr: %s", err))
 	}
-	return nil
+	return err
 }
 
 const countryCode = "JP"
@@ -48,7 +50,7 @@ func validateCountryCodeIsJP(c *mpm.Code) error {
 	if c.CountryCode == countryCode {
 		return nil
 	}
-	return mpm.NewInvalidFormat(fmt.Sprintf("jpqr: CountryCode should be %s", countryCode))
+	return mpm.NewFieldError("58", "CountryCode", c.CountryCode, mpm.ReasonValue, fmt.Sprintf("jpqr: CountryCode should be %s", countryCode))
 }
 
 const transactionCurrency = "392"
@@ -57,12 +59,12 @@ func validateTransactionCurrency(c *mpm.Code) error {
 	if c.TransactionCurrency == transactionCurrency {
 		return nil
 	}
-	return mpm.NewInvalidFormat(fmt.Sprintf("jpqr: TransactionCurrency should be %s", transactionCurrency))
+	return mpm.NewFieldError("53", "TransactionCurrency", c.TransactionCurrency, mpm.ReasonValue, fmt.Sprintf("jpqr: TransactionCurrency should be %s", transactionCurrency))
 }
 
 func validatePostalCode(c *mpm.Code) error {
 	if c.PostalCode == "" {
-		return mpm.NewInvalidFormat("jpqr: PostalCode should be represented")
+		return mpm.NewFieldError("61", "PostalCode", c.PostalCode, mpm.ReasonMissing, "jpqr: PostalCode should be represented")
 	}
 	return nil
 }
@@ -71,13 +73,13 @@ const languagePreference = "JA"
 
 func validateMerchantInformation(c *mpm.Code) error {
 	if !c.MerchantInformation.Valid {
-		return mpm.NewInvalidFormat("jpqr: MerchantInformation should be represented")
+		return mpm.NewFieldError("64", "MerchantInformation", "", mpm.ReasonMissing, "jpqr: MerchantInformation should be represented")
 	}
 	if c.MerchantInformation.LanguagePreference != languagePreference {
-		return mpm.NewInvalidFormat(fmt.Sprintf("jpqr: MerchantInformation.LanguagePreference should be %s", languagePreference))
+		return mpm.NewFieldError("64.00", "MerchantInformation.LanguagePreference", c.MerchantInformation.LanguagePreference, mpm.ReasonValue, fmt.Sprintf("jpqr: MerchantInformation.LanguagePreference should be %s", languagePreference))
 	}
 	if c.MerchantInformation.City != "" {
-		return mpm.NewInvalidFormat("jpqr: MerchantInformation.City is not necessary")
+		return mpm.NewFieldError("64.02", "MerchantInformation.City", c.MerchantInformation.City, mpm.ReasonValue, "jpqr: MerchantInformation.City is not necessary")
 	}
 	return nil
 }
@@ -1,6 +1,7 @@
 package jpqr_test
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -81,6 +82,41 @@ func TestDecode(t *testing.T) {
 	}
 }
 
+func TestEncode_missingID(t *testing.T) {
+	c := &mpm.Code{
+		PayloadFormatIndicator:  "01",
+		PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+		MerchantAccountInformation: []tlv.TLV{
+			{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+		},
+		MerchantCategoryCode: "4111",
+		TransactionCurrency:  "392",
+		CountryCode:          "JP",
+		MerchantName:         "xxx",
+		MerchantCity:         "xxx",
+	}
+	_, err := jpqr.Encode(c)
+	if err == nil {
+		t.Fatal("Encode() should return error")
+	}
+
+	// Callers written against the former *genericError keep working.
+	if !errors.Is(err, mpm.InvalidFormat) {
+		t.Errorf("errors.Is(%v, mpm.InvalidFormat) = false, want true", err)
+	}
+	if e, ok := err.(interface{ InvalidFormat() bool }); !ok || !e.InvalidFormat() {
+		t.Errorf("Encode() error = %#v, want InvalidFormat() true", err)
+	}
+	// The error has never been a comparable sentinel, so == does not match it.
+	if err == mpm.InvalidFormat {
+		t.Errorf("Encode() error == mpm.InvalidFormat, want a *mpm.FieldError")
+	}
+	var e *mpm.FieldError
+	if !errors.As(err, &e) || e.Reason != mpm.ReasonMissing {
+		t.Errorf("Encode() error = %#v, want *mpm.FieldError of Reason %s", err, mpm.ReasonMissing)
+	}
+}
+
 func TestEncode(t *testing.T) {
 	type args struct {
 		code *mpm.Code
@@ -125,13 +161,18 @@ func TestEncode(t *testing.T) {
 					MerchantAccountInformation: []tlv.TLV{
 						{Tag: "27", Length: "68", Value: "0019jp.or.paymentsjapan011300000000000010204000103060000010406000001"},
 					},
-					MerchantCategoryCode:        "4111",
-					TransactionCurrency:         "392",
-					CountryCode:                 "CN",
-					MerchantName:                "BEST TRANSPORT",
-					MerchantCity:                "BEIJING",
-					PostalCode:                  "",
-					AdditionalDataFieldTemplate: "030412340603***0708A60086670902ME",
+					MerchantCategoryCode: "4111",
+					TransactionCurrency:  "392",
+					CountryCode:          "CN",
+					MerchantName:         "BEST TRANSPORT",
+					MerchantCity:         "BEIJING",
+					PostalCode:           "",
+					AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+						StoreLabel:                    "1234",
+						CustomerLabel:                 "***",
+						TerminalLabel:                 "A6008667",
+						AdditionalConsumerDataRequest: "ME",
+					},
 					MerchantInformation: mpm.NullMerchantInformation{
 						LanguagePreference: "ZH",
 						Name:               "最佳运输",
@@ -151,13 +192,18 @@ func TestEncode(t *testing.T) {
 					MerchantAccountInformation: []tlv.TLV{
 						{Tag: "28", Length: "68", Value: "0019jp.or.paymentsjapan011300000000000010204000103060000010406000001"},
 					},
-					MerchantCategoryCode:        "4111",
-					TransactionCurrency:         "156",
-					CountryCode:                 "JP",
-					MerchantName:                "BEST TRANSPORT",
-					MerchantCity:                "BEIJING",
-					PostalCode:                  "",
-					AdditionalDataFieldTemplate: "030412340603***0708A60086670902ME",
+					MerchantCategoryCode: "4111",
+					TransactionCurrency:  "156",
+					CountryCode:          "JP",
+					MerchantName:         "BEST TRANSPORT",
+					MerchantCity:         "BEIJING",
+					PostalCode:           "",
+					AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+						StoreLabel:                    "1234",
+						CustomerLabel:                 "***",
+						TerminalLabel:                 "A6008667",
+						AdditionalConsumerDataRequest: "ME",
+					},
 					MerchantInformation: mpm.NullMerchantInformation{
 						LanguagePreference: "ZH",
 						Name:               "最佳运输",
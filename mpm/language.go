@@ -0,0 +1,111 @@
+package mpm
+
+import (
+	"fmt"
+	"strings"
+
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+const (
+	merchantInformationID = "64"
+
+	alternateMerchantInformationTagName = "AlternateMerchantInformation"
+)
+
+// AlternateMerchantInformation represents Merchant Information—Language Templates following Code.MerchantInformation.
+// Some schemes repeat ID 64 to provide the merchant name and city in several alternate languages.
+// Only repeated data objects of ID 64 are taken as language templates. Language blocks carried in
+// RFU for EMVCo or unreserved templates follow scheme specific layouts and are kept as is in
+// Code.RFUForEMVCo and Code.UnreservedTemplates.
+type AlternateMerchantInformation []NullMerchantInformation
+
+// Scan appends the Merchant Information—Language Template in token.
+func (a *AlternateMerchantInformation) Scan(token []rune) error {
+	return a.ScanUnit(token, tlv.LengthUnitRune)
+}
+
+// ScanUnit appends the Merchant Information—Language Template in token of Lengths in unit.
+func (a *AlternateMerchantInformation) ScanUnit(token []rune, unit tlv.LengthUnit) error {
+	var m NullMerchantInformation
+	if err := m.ScanUnit(token, unit); err != nil {
+		return err
+	}
+	*a = append(*a, m)
+	return nil
+}
+
+// Tokenize turns AlternateMerchantInformation into a string of data objects of ID 64.
+func (a *AlternateMerchantInformation) Tokenize() (string, error) {
+	return a.TokenizeUnit(tlv.LengthUnitRune)
+}
+
+// TokenizeUnit turns AlternateMerchantInformation into a string of data objects of ID 64 of Lengths in unit.
+func (a *AlternateMerchantInformation) TokenizeUnit(unit tlv.LengthUnit) (string, error) {
+	if a == nil {
+		return "", nil
+	}
+	var b strings.Builder
+	for i := range *a {
+		v, err := (*a)[i].TokenizeUnit(unit)
+		if err != nil {
+			return "", err
+		}
+		if v == "" {
+			continue
+		}
+		b.WriteString(merchantInformationID)
+		b.WriteString(fmt.Sprintf("%02d", unit.Len(v)))
+		b.WriteString(v)
+	}
+	return b.String(), nil
+}
+
+// MerchantInformationTemplates returns the valid Merchant Information—Language Templates in order of appearance.
+func (c *Code) MerchantInformationTemplates() []NullMerchantInformation {
+	var s []NullMerchantInformation
+	if c.MerchantInformation.Valid {
+		s = append(s, c.MerchantInformation)
+	}
+	for _, m := range c.AlternateMerchantInformation {
+		if m.Valid {
+			s = append(s, m)
+		}
+	}
+	return s
+}
+
+// MerchantInformationByLanguage returns the Merchant Information—Language Template of the ISO 639-1 code.
+// Language codes are compared case-insensitively.
+func (c *Code) MerchantInformationByLanguage(language string) (NullMerchantInformation, bool) {
+	for _, m := range c.MerchantInformationTemplates() {
+		if strings.EqualFold(m.LanguagePreference, language) {
+			return m, true
+		}
+	}
+	return NullMerchantInformation{}, false
+}
+
+// IsLanguageCode reports whether code is an ISO 639-1 two-letter language code.
+// Codes are compared case-insensitively.
+func IsLanguageCode(code string) bool {
+	_, ok := languageCodes[strings.ToLower(code)]
+	return len(code) == 2 && ok
+}
+
+// languageCodes is the set of ISO 639-1 codes.
+var languageCodes = func() map[string]struct{} {
+	codes := strings.Fields(`
+		aa ab ae af ak am an ar as av ay az ba be bg bi bm bn bo br bs ca ce ch co cr cs cu cv cy
+		da de dv dz ee el en eo es et eu fa ff fi fj fo fr fy ga gd gl gn gu gv ha he hi ho hr ht
+		hu hy hz ia id ie ig ii ik io is it iu ja jv ka kg ki kj kk kl km kn ko kr ks ku kv kw ky
+		la lb lg li ln lo lt lu lv mg mh mi mk ml mn mr ms mt my na nb nd ne ng nl nn no nr nv ny
+		oc oj om or os pa pi pl ps pt qu rm rn ro ru rw sa sc sd se sg si sk sl sm sn so sq sr ss
+		st su sv sw ta te tg th ti tk tl tn to tr ts tt tw ty ug uk ur uz ve vi vo wa wo xh yi yo
+		za zh zu`)
+	m := make(map[string]struct{}, len(codes))
+	for _, c := range codes {
+		m[c] = struct{}{}
+	}
+	return m
+}()
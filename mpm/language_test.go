@@ -0,0 +1,110 @@
+package mpm_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+func TestCode_AlternateMerchantInformation(t *testing.T) {
+	c := &mpm.Code{
+		PayloadFormatIndicator:  "01",
+		PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+		MerchantAccountInformation: []tlv.TLV{
+			{Tag: "04", Length: "16", Value: "4000123456789012"},
+			{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+		},
+		MerchantCategoryCode:            "4111",
+		TransactionCurrency:             "156",
+		TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+		TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+		ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+		CountryCode:                     "CN",
+		MerchantName:                    "BEST TRANSPORT",
+		MerchantCity:                    "BEIJING",
+		PostalCode:                      "100000",
+		AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+			StoreLabel:                    "1234",
+			AdditionalConsumerDataRequest: "ME",
+		},
+		MerchantInformation: mpm.NullMerchantInformation{LanguagePreference: "ZH", Name: "最佳运输", City: "北京", Valid: true},
+		AlternateMerchantInformation: mpm.AlternateMerchantInformation{
+			{LanguagePreference: "JA", Name: "ベスト運輸", City: "北京", Valid: true},
+			{LanguagePreference: "KO", Name: "베스트 운송", City: "베이징", Valid: true},
+		},
+	}
+
+	buf, err := mpm.Encode(c)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	want := "64200002ZH0104最佳运输0202北京64210002JA0105ベスト運輸0202北京64230002KO0106베스트 운송0203베이징"
+	if !bytes.Contains(buf, []byte(want)) {
+		t.Errorf("Encode() = %s, want to contain %s", buf, want)
+	}
+
+	got, err := mpm.Decode(buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, c) {
+		t.Errorf("Decode() = %+v, want %+v", got, c)
+	}
+
+	fields := map[string]tlv.LengthUnit{"64": tlv.LengthUnitByte}
+	buf, err = (&mpm.Encoder{FieldLengthUnits: fields}).Encode(c)
+	if err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	want = "64320002ZH0112最佳运输0206北京64350002JA0115ベスト運輸0206北京64390002KO0116베스트 운송0209베이징"
+	if !bytes.Contains(buf, []byte(want)) {
+		t.Errorf("Encoder.Encode() = %s, want to contain %s", buf, want)
+	}
+	if got, err := (&mpm.Decoder{FieldLengthUnits: fields}).Decode(buf); err != nil || !reflect.DeepEqual(got, c) {
+		t.Errorf("Decoder.Decode() = %+v, %v, want %+v", got, err, c)
+	}
+
+	tests := []struct {
+		language string
+		want     string
+		wantOK   bool
+	}{
+		{language: "zh", want: "最佳运输", wantOK: true},
+		{language: "JA", want: "ベスト運輸", wantOK: true},
+		{language: "ko", want: "베스트 운송", wantOK: true},
+		{language: "en"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.language, func(t *testing.T) {
+			m, ok := got.MerchantInformationByLanguage(tt.language)
+			if ok != tt.wantOK || m.Name != tt.want {
+				t.Errorf("MerchantInformationByLanguage() = %v, %t, want %s, %t", m, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestIsLanguageCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{code: "ja", want: true},
+		{code: "ZH", want: true},
+		{code: "xx"},
+		{code: "jpn"},
+		{code: ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.code, func(t *testing.T) {
+			if got := mpm.IsLanguageCode(tt.code); got != tt.want {
+				t.Errorf("IsLanguageCode(%q) = %t, want %t", tt.code, got, tt.want)
+			}
+		})
+	}
+}
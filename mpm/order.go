@@ -0,0 +1,100 @@
+package mpm
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+// DataObjects returns the root data objects in the order of the decoded payload,
+// excluding Payload Format Indicator and CRC.
+// It returns nil unless the Code is decoded with Decoder.PreserveOrder.
+func (c *Code) DataObjects() []tlv.TLV {
+	if c.raw == nil {
+		return nil
+	}
+	return append([]tlv.TLV{}, c.raw[:len(c.raw)-1]...)
+}
+
+// rawCRC returns the CRC of the decoded payload as is, or "" unless the Code is decoded with Decoder.PreserveOrder.
+func (c *Code) rawCRC() string {
+	if c.raw == nil {
+		return ""
+	}
+	return c.raw[len(c.raw)-1].Value
+}
+
+// splitDataObjects splits b into data objects. It returns false if b is malformed.
+func splitDataObjects(b []byte, unitOf func(id string) tlv.LengthUnit) ([]tlv.TLV, bool) {
+	s := []tlv.TLV{}
+	for len(b) != 0 {
+		if len(b) < tagLength+lenLength {
+			return nil, false
+		}
+		id := string(b[:tagLength])
+		_, value, ok := findDataObject(b, id, unitOf)
+		if !ok {
+			return nil, false
+		}
+		s = append(s, tlv.TLV{Tag: id, Length: string(b[tagLength : tagLength+lenLength]), Value: string(value)})
+		b = b[tagLength+lenLength+len(value):]
+	}
+	return s, true
+}
+
+// reorderDataObjects reorders the root data objects of b, encoded in the order of the specification, into the order of raw.
+// Data objects equivalent to the raw ones are written as the raw ones, and the ones missing in raw follow them.
+func (e *Encoder) reorderDataObjects(b []byte, raw []tlv.TLV) []byte {
+	objs, ok := splitDataObjects(b, e.lengthUnitOf)
+	if !ok {
+		return b
+	}
+	used := make([]bool, len(objs))
+	var buf bytes.Buffer
+	buf.Grow(len(b))
+	for _, r := range raw {
+		for i, o := range objs {
+			if used[i] || o.Tag != r.Tag {
+				continue
+			}
+			used[i] = true
+			if e.sameDataObject(r, o) {
+				o = r
+			}
+			buf.WriteString(o.Tag + o.Length + o.Value)
+			break
+		}
+	}
+	for i, o := range objs {
+		if !used[i] {
+			buf.WriteString(o.Tag + o.Length + o.Value)
+		}
+	}
+	return buf.Bytes()
+}
+
+// sameDataObject reports whether a and b decode into the same value, e.g. templates of the same data objects in different order.
+func (e *Encoder) sameDataObject(a, b tlv.TLV) bool {
+	if a == b {
+		return true
+	}
+	translatorFunc := chainTagLengthTranslators(
+		merchantAccountInformation,
+		rfuForEMVCo,
+		unreservedTemplates,
+	)
+	var ca, cb Code
+	for _, v := range []struct {
+		t tlv.TLV
+		c *Code
+	}{{a, &ca}, {b, &cb}} {
+		dec := tlv.NewDecoder(strings.NewReader(v.t.Tag+v.t.Length+v.t.Value), tagName, MaxSize, tagLength, lenLength, translatorFunc)
+		setLengthUnits(dec, e.LengthUnit, e.FieldLengthUnits)
+		if err := dec.Decode(v.c); err != nil {
+			return false
+		}
+	}
+	return reflect.DeepEqual(ca, cb)
+}
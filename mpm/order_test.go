@@ -0,0 +1,97 @@
+package mpm_test
+
+import (
+	"testing"
+
+	"go.mercari.io/go-emv-code/mpm"
+)
+
+func TestDecoder_PreserveOrder(t *testing.T) {
+	payload := "00020152044111530315601021229300012D156000000000510A93FO3230Q5802CN6007BEIJING5914BEST TRANSPORT62140902ME0304123464200104最佳运输0002ZH0202北京630410B1"
+	canonical := "00020101021229300012D156000000000510A93FO3230Q5204411153031565802CN5914BEST TRANSPORT6007BEIJING6214030412340902ME64200002ZH0104最佳运输0202北京63044EAB"
+	lower := "00020152044111530315601021229300012D156000000000510A93FO3230Q5802CN6007BEIJING5914BEST TRANSPORT62140902ME0304123464200104最佳运输0002ZH0202北京630410b1"
+
+	tests := []struct {
+		name    string
+		give    string
+		dec     mpm.Decoder
+		enc     mpm.Encoder
+		modify  func(*mpm.Code)
+		want    string
+		wantLen int
+	}{
+		{
+			name:    "preserve order",
+			give:    payload,
+			dec:     mpm.Decoder{PreserveOrder: true},
+			want:    payload,
+			wantLen: 9,
+		},
+		{
+			name:    "modified data object keeps its position",
+			give:    payload,
+			dec:     mpm.Decoder{PreserveOrder: true},
+			modify:  func(c *mpm.Code) { c.MerchantCity = "SHANGHAI" },
+			want:    "00020152044111530315601021229300012D156000000000510A93FO3230Q5802CN6008SHANGHAI5914BEST TRANSPORT62140902ME0304123464200104最佳运输0002ZH0202北京63040DB0",
+			wantLen: 9,
+		},
+		{
+			name:    "canonicalize",
+			give:    payload,
+			dec:     mpm.Decoder{PreserveOrder: true},
+			enc:     mpm.Encoder{Canonicalize: true},
+			want:    canonical,
+			wantLen: 9,
+		},
+		{
+			name:    "preserve lowercase CRC",
+			give:    lower,
+			dec:     mpm.Decoder{PreserveOrder: true},
+			want:    lower,
+			wantLen: 9,
+		},
+		{
+			name:    "modified data object with lowercase CRC",
+			give:    lower,
+			dec:     mpm.Decoder{PreserveOrder: true},
+			modify:  func(c *mpm.Code) { c.MerchantCity = "SHANGHAI" },
+			want:    "00020152044111530315601021229300012D156000000000510A93FO3230Q5802CN6008SHANGHAI5914BEST TRANSPORT62140902ME0304123464200104最佳运输0002ZH0202北京63040DB0",
+			wantLen: 9,
+		},
+		{
+			name:    "canonicalize lowercase CRC",
+			give:    lower,
+			dec:     mpm.Decoder{PreserveOrder: true},
+			enc:     mpm.Encoder{Canonicalize: true},
+			want:    canonical,
+			wantLen: 9,
+		},
+		{
+			name: "without PreserveOrder",
+			give: payload,
+			want: canonical,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := tt.dec.Decode([]byte(tt.give))
+			if err != nil {
+				t.Fatalf("Decoder.Decode() error = %v", err)
+			}
+			if got := len(c.DataObjects()); got != tt.wantLen {
+				t.Errorf("len(Code.DataObjects()) = %d, want %d", got, tt.wantLen)
+			}
+			if tt.modify != nil {
+				tt.modify(c)
+			}
+			buf, err := tt.enc.Encode(c)
+			if err != nil {
+				t.Fatalf("Encoder.Encode() error = %v", err)
+			}
+			if string(buf) != tt.want {
+				t.Errorf("Encoder.Encode() = %s, want %s", buf, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package pix
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+const (
+	gui       = "br.gov.bcb.pix"
+	accountID = "26"
+
+	urlMaxLength = 77
+)
+
+// Account represents a parsed Pix Merchant Account Information template.
+// A static BR Code has Key and an optional Description, and a dynamic one has URL instead.
+type Account struct {
+	Key         string // chave
+	Description string // informação adicional
+	URL         string // location of the payload of a dynamic BR Code without the scheme
+}
+
+var errMissingAccount = errors.New("missing Pix account")
+
+// String returns the accumulated string.
+func (a *Account) String() string {
+	v, _ := a.template().Tokenize()
+	return v
+}
+
+func (a *Account) template() *mpm.MerchantAccountInformationTemplate {
+	m := &mpm.MerchantAccountInformationTemplate{ID: accountID, GloballyUniqueIdentifier: gui}
+	for _, v := range []struct{ tag, value string }{
+		{"01", a.Key},
+		{"02", a.Description},
+		{"25", a.URL},
+	} {
+		if v.value != "" {
+			m.Data = append(m.Data, tlv.TLV{Tag: v.tag, Value: v.value})
+		}
+	}
+	return m
+}
+
+// Dynamic reports whether the account is of a dynamic BR Code.
+func (a *Account) Dynamic() bool {
+	return a.URL != ""
+}
+
+// validateAccountFormat validates a of the Merchant Account Information template of id.
+func validateAccountFormat(id string, a *Account) error {
+	switch {
+	case a.Key != "" && a.URL != "":
+		return accountError(id, a.Key, mpm.ReasonValue, "account should have either key or URL")
+	case a.URL != "":
+		if urlMaxLength < len(a.URL) {
+			return accountError(id, a.URL, mpm.ReasonLength, fmt.Sprintf("len(URL) should be at most %d", urlMaxLength))
+		}
+		if strings.Contains(a.URL, "://") {
+			return accountError(id, a.URL, mpm.ReasonValue, "URL should not have the scheme")
+		}
+		if a.Description != "" {
+			return accountError(id, a.Description, mpm.ReasonUnexpected, "description is not acceptable with URL")
+		}
+	case a.Key != "":
+		if _, ok := DetectKeyType(a.Key); !ok {
+			return accountError(id, a.Key, mpm.ReasonValue, fmt.Sprintf("unknown type of key %q", a.Key))
+		}
+	default:
+		return accountError(id, "", mpm.ReasonMissing, "account should have either key or URL")
+	}
+	return nil
+}
+
+func accountError(id, value string, reason mpm.Reason, msg string) error {
+	return mpm.NewFieldError(id, "MerchantAccountInformation", value, reason, "pix: "+msg)
+}
+
+func init() {
+	mpm.RegisterScheme(mpm.Scheme{
+		Name:  "Pix",
+		Match: mpm.MatchGUID(gui),
+		Parse: parseAccount,
+	})
+}
+
+func parseAccount(m *mpm.MerchantAccountInformationTemplate) (interface{}, error) {
+	var a Account
+	a.Key, _ = m.Value("01")
+	a.Description, _ = m.Value("02")
+	a.URL, _ = m.Value("25")
+	if err := validateAccountFormat(m.ID, &a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// ParseAccount validates and parses given *mpm.Code as Pix account.
+// If the account is malformed, it returns *mpm.FieldError of the ID of the template.
+func ParseAccount(c *mpm.Code) (*Account, error) {
+	v, err := c.AccountInformation(gui)
+	if errors.Is(err, mpm.ErrAccountInformationNotFound) {
+		return nil, errMissingAccount
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Account), nil
+}
@@ -0,0 +1,25 @@
+package pix_test
+
+import (
+	"fmt"
+	"log"
+
+	"go.mercari.io/go-emv-code/mpm/pix"
+)
+
+func ExampleStaticCode_Encode() {
+	s := pix.StaticCode{
+		Key:          "123e4567-e12b-12d1-a456-426655440000",
+		MerchantName: "Fulano de Tal",
+		MerchantCity: "BRASILIA",
+	}
+	buf, err := s.Encode()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(string(buf))
+
+	// Output:
+	// 00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D
+}
@@ -0,0 +1,75 @@
+package pix
+
+import (
+	"regexp"
+	"strings"
+
+	"go.mercari.io/go-emv-code/mpm"
+)
+
+// KeyType represents the type of Pix key (chave).
+type KeyType string
+
+const (
+	KeyTypeCPF   KeyType = "CPF"   // 11 digits of an individual taxpayer
+	KeyTypeCNPJ  KeyType = "CNPJ"  // 14 digits of a legal entity
+	KeyTypeEmail KeyType = "email" // e-mail address
+	KeyTypePhone KeyType = "phone" // +55 followed by the area code and the number
+	KeyTypeEVP   KeyType = "EVP"   // random key in the form of UUID
+)
+
+const keyMaxLength = 77
+
+var (
+	phonePattern = regexp.MustCompile(`^\+55[0-9]{10,11}$`)
+	emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	evpPattern   = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// DetectKeyType returns the type of Pix key.
+// CPF and CNPJ keys are digits only and their check digits should be valid.
+func DetectKeyType(key string) (KeyType, bool) {
+	switch {
+	case keyMaxLength < len(key):
+		return "", false
+	case isCPF(key):
+		return KeyTypeCPF, true
+	case isCNPJ(key):
+		return KeyTypeCNPJ, true
+	case phonePattern.MatchString(key):
+		return KeyTypePhone, true
+	case evpPattern.MatchString(key):
+		return KeyTypeEVP, true
+	case emailPattern.MatchString(key):
+		return KeyTypeEmail, true
+	}
+	return "", false
+}
+
+func isCPF(s string) bool {
+	if len(s) != 11 || !mpm.IsNumeric(s) || strings.Count(s, s[:1]) == len(s) {
+		return false
+	}
+	return checkDigit(s[:9], []int{10, 9, 8, 7, 6, 5, 4, 3, 2}) == s[9] &&
+		checkDigit(s[:10], []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2}) == s[10]
+}
+
+func isCNPJ(s string) bool {
+	if len(s) != 14 || !mpm.IsNumeric(s) || strings.Count(s, s[:1]) == len(s) {
+		return false
+	}
+	return checkDigit(s[:12], []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == s[12] &&
+		checkDigit(s[:13], []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == s[13]
+}
+
+// checkDigit returns the modulo 11 check digit of digits by weights.
+func checkDigit(digits string, weights []int) byte {
+	var sum int
+	for i, w := range weights {
+		sum += int(digits[i]-'0') * w
+	}
+	if r := sum % 11; r >= 2 {
+		return byte('0' + 11 - r)
+	}
+	return '0'
+}
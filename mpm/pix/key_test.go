@@ -0,0 +1,36 @@
+package pix_test
+
+import (
+	"testing"
+
+	"go.mercari.io/go-emv-code/mpm/pix"
+)
+
+func TestDetectKeyType(t *testing.T) {
+	tests := []struct {
+		key    string
+		want   pix.KeyType
+		wantOK bool
+	}{
+		{key: "12345678909", want: pix.KeyTypeCPF, wantOK: true},
+		{key: "11222333000181", want: pix.KeyTypeCNPJ, wantOK: true},
+		{key: "fulano@example.com", want: pix.KeyTypeEmail, wantOK: true},
+		{key: "+5561912345678", want: pix.KeyTypePhone, wantOK: true},
+		{key: "123e4567-e12b-12d1-a456-426655440000", want: pix.KeyTypeEVP, wantOK: true},
+		{key: "12345678900"},
+		{key: "11111111111"},
+		{key: "11222333000180"},
+		{key: "61912345678"},
+		{key: "fulano"},
+		{key: ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.key, func(t *testing.T) {
+			got, ok := pix.DetectKeyType(tt.key)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("DetectKeyType() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
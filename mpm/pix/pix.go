@@ -0,0 +1,177 @@
+/*
+Package pix implements encoding and decoding of BR Code, the QR Code of Pix as defined in
+Manual de Padrões para Iniciação do Pix by Banco Central do Brasil.
+*/
+package pix
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+
+	"go.mercari.io/go-emv-code/mpm"
+)
+
+var validators = []mpm.ValidatorFunc{
+	validateAccount,
+	mpm.CountryCodeValidator(countryCode),
+	mpm.TransactionCurrencyValidator(transactionCurrency),
+	validateMerchantName,
+	validateMerchantCity,
+	validateTxID,
+}
+
+// Decode decodes payload and validates as BR Code.
+func Decode(payload []byte) (*mpm.Code, error) {
+	c, err := mpm.Decode(payload, validators...)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Encode encodes to EMV Payment Code payload.
+func Encode(c *mpm.Code) ([]byte, error) {
+	return mpm.Encode(c, validators...)
+}
+
+const (
+	merchantCategoryCode = "0000"
+	countryCode          = "BR"
+	transactionCurrency  = "986"
+	transactionExponent  = 2
+
+	// TxIDUnspecified is the txid of BR Codes without a transaction identifier, and of all dynamic ones.
+	TxIDUnspecified = "***"
+)
+
+// StaticCode represents a static BR Code, which transfers to Key.
+type StaticCode struct {
+	Key          string
+	Description  string
+	MerchantName string
+	MerchantCity string
+	TxID         string      // TxIDUnspecified if empty
+	Amount       *mpm.Amount // the consumer enters the amount if nil
+}
+
+// Code returns the BR Code as *mpm.Code.
+func (s *StaticCode) Code() (*mpm.Code, error) {
+	txID := s.TxID
+	if txID == "" {
+		txID = TxIDUnspecified
+	}
+	a := Account{Key: s.Key, Description: s.Description}
+	return code(&a, s.MerchantName, s.MerchantCity, txID, s.Amount, mpm.PointOfInitiationMethodStatic)
+}
+
+// Encode encodes the BR Code to EMV Payment Code payload.
+func (s *StaticCode) Encode() ([]byte, error) {
+	c, err := s.Code()
+	if err != nil {
+		return nil, err
+	}
+	return Encode(c)
+}
+
+// DynamicCode represents a dynamic BR Code, whose payment details are served at URL.
+type DynamicCode struct {
+	URL          string
+	MerchantName string
+	MerchantCity string
+	Amount       *mpm.Amount // optional, for display only
+}
+
+// Code returns the BR Code as *mpm.Code.
+func (d *DynamicCode) Code() (*mpm.Code, error) {
+	a := Account{URL: d.URL}
+	return code(&a, d.MerchantName, d.MerchantCity, TxIDUnspecified, d.Amount, mpm.PointOfInitiationMethodDynamic)
+}
+
+// Encode encodes the BR Code to EMV Payment Code payload.
+func (d *DynamicCode) Encode() ([]byte, error) {
+	c, err := d.Code()
+	if err != nil {
+		return nil, err
+	}
+	return Encode(c)
+}
+
+func code(a *Account, name, city, txID string, amount *mpm.Amount, poi mpm.PointOfInitiationMethod) (*mpm.Code, error) {
+	if err := validateAccountFormat(accountID, a); err != nil {
+		return nil, err
+	}
+	t, err := a.template().TLV()
+	if err != nil {
+		return nil, err
+	}
+	c := &mpm.Code{
+		PayloadFormatIndicator: "01",
+		MerchantCategoryCode:   merchantCategoryCode,
+		TransactionCurrency:    transactionCurrency,
+		CountryCode:            countryCode,
+		MerchantName:           name,
+		MerchantCity:           city,
+	}
+	c.MerchantAccountInformation = append(c.MerchantAccountInformation, t)
+	c.AdditionalDataFieldTemplate.ReferenceLabel = txID
+	if poi == mpm.PointOfInitiationMethodDynamic {
+		// Only dynamic BR Codes are flagged; static ones omit Point of Initiation Method.
+		c.PointOfInitiationMethod = poi
+	}
+	if amount != nil {
+		if amount.Exponent != transactionExponent || !amount.Valid() {
+			return nil, fmt.Errorf("pix: amount %s should be a non-negative amount of exponent %d", amount, transactionExponent)
+		}
+		c.TransactionAmount = mpm.NullString{String: amount.String(), Valid: true}
+	}
+	return c, nil
+}
+
+func validateAccount(c *mpm.Code) error {
+	a, err := ParseAccount(c)
+	if errors.Is(err, errMissingAccount) {
+		return mpm.NewFieldError("", "MerchantAccountInformation", "", mpm.ReasonMissing, fmt.Sprintf("pix: %s", err))
+	}
+	if err != nil {
+		return err
+	}
+	if a.Dynamic() && c.AdditionalDataFieldTemplate.ReferenceLabel != TxIDUnspecified {
+		return mpm.NewFieldError("62.05", "AdditionalDataFieldTemplate.ReferenceLabel", c.AdditionalDataFieldTemplate.ReferenceLabel, mpm.ReasonValue, fmt.Sprintf("pix: txid of dynamic BR Code should be %s", TxIDUnspecified))
+	}
+	return nil
+}
+
+const (
+	merchantNameMaxLength = 25
+	merchantCityMaxLength = 15
+	txIDMaxLength         = 25
+)
+
+func validateMerchantName(c *mpm.Code) error {
+	if merchantNameMaxLength < utf8.RuneCountInString(c.MerchantName) {
+		return mpm.NewFieldError("59", "MerchantName", c.MerchantName, mpm.ReasonLength, fmt.Sprintf("pix: length of MerchantName should be at most %d", merchantNameMaxLength))
+	}
+	return nil
+}
+
+func validateMerchantCity(c *mpm.Code) error {
+	if merchantCityMaxLength < utf8.RuneCountInString(c.MerchantCity) {
+		return mpm.NewFieldError("60", "MerchantCity", c.MerchantCity, mpm.ReasonLength, fmt.Sprintf("pix: length of MerchantCity should be at most %d", merchantCityMaxLength))
+	}
+	return nil
+}
+
+var txIDPattern = regexp.MustCompile(`^[0-9A-Za-z]{1,25}$`)
+
+func validateTxID(c *mpm.Code) error {
+	v := c.AdditionalDataFieldTemplate.ReferenceLabel
+	if v == "" {
+		return mpm.NewFieldError("62.05", "AdditionalDataFieldTemplate.ReferenceLabel", v, mpm.ReasonMissing, fmt.Sprintf("pix: txid should be represented, or be %s if unspecified", TxIDUnspecified))
+	}
+	if v != TxIDUnspecified && !txIDPattern.MatchString(v) {
+		return mpm.NewFieldError("62.05", "AdditionalDataFieldTemplate.ReferenceLabel", v, mpm.ReasonValue, fmt.Sprintf("pix: txid should be alphanumeric of at most %d characters", txIDMaxLength))
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package pix_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/mpm/pix"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    *pix.Account
+		wantErr bool
+	}{
+		{
+			name:    "pass: static",
+			payload: "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D",
+			want:    &pix.Account{Key: "123e4567-e12b-12d1-a456-426655440000"},
+		},
+		{
+			name:    "pass: dynamic",
+			payload: "00020101021226620014br.gov.bcb.pix2540pix.example.com/qr/v2/9d36b84fc70b478fb95204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63047CDE",
+			want:    &pix.Account{URL: "pix.example.com/qr/v2/9d36b84fc70b478fb9"},
+		},
+		{
+			name:    "err: JPQR",
+			payload: "0002016003xxx01021129300012D156000000000510A93FO3230Q31280012D1560000000103081234567826680019jp.or.paymentsjapan01130000000000001020400010306000001040600000153033925903xxx64180002JA0108メルペイ カフェ520441115802JP610710661436304DEE9",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := pix.Decode([]byte(tt.payload))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := pix.ParseAccount(c)
+			if err != nil {
+				t.Fatalf("ParseAccount() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAccount() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStaticCode_Encode(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    pix.StaticCode
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "pass: EVP",
+			code: pix.StaticCode{
+				Key:          "123e4567-e12b-12d1-a456-426655440000",
+				MerchantName: "Fulano de Tal",
+				MerchantCity: "BRASILIA",
+			},
+			want: "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D",
+		},
+		{
+			name: "pass: phone with amount and txid",
+			code: pix.StaticCode{
+				Key:          "+5561912345678",
+				MerchantName: "Fulano de Tal",
+				MerchantCity: "BRASILIA",
+				TxID:         "PEDIDO1234",
+				Amount:       &mpm.Amount{MinorUnits: 12345, Exponent: 2},
+			},
+			want: "00020126360014br.gov.bcb.pix0114+55619123456785204000053039865406123.455802BR5913Fulano de Tal6008BRASILIA62140510PEDIDO12346304AC77",
+		},
+		{
+			name:    "err: unknown type of key",
+			code:    pix.StaticCode{Key: "12345678900", MerchantName: "Fulano de Tal", MerchantCity: "BRASILIA"},
+			wantErr: true,
+		},
+		{
+			name:    "err: MerchantCity is too long",
+			code:    pix.StaticCode{Key: "12345678909", MerchantName: "Fulano de Tal", MerchantCity: "SAO JOSE DOS CAMPOS"},
+			wantErr: true,
+		},
+		{
+			name:    "err: txid is not alphanumeric",
+			code:    pix.StaticCode{Key: "12345678909", MerchantName: "Fulano de Tal", MerchantCity: "BRASILIA", TxID: "PEDIDO-1234"},
+			wantErr: true,
+		},
+		{
+			name: "err: amount of another exponent",
+			code: pix.StaticCode{
+				Key:          "12345678909",
+				MerchantName: "Fulano de Tal",
+				MerchantCity: "BRASILIA",
+				Amount:       &mpm.Amount{MinorUnits: 100, Exponent: 0},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.code.Encode()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("StaticCode.Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("StaticCode.Encode() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDynamicCode_Encode(t *testing.T) {
+	d := pix.DynamicCode{
+		URL:          "pix.example.com/qr/v2/9d36b84fc70b478fb9",
+		MerchantName: "Fulano de Tal",
+		MerchantCity: "BRASILIA",
+	}
+	got, err := d.Encode()
+	if err != nil {
+		t.Fatalf("DynamicCode.Encode() error = %v", err)
+	}
+	if want := "00020101021226620014br.gov.bcb.pix2540pix.example.com/qr/v2/9d36b84fc70b478fb95204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63047CDE"; string(got) != want {
+		t.Errorf("DynamicCode.Encode() = %s, want %s", got, want)
+	}
+
+	d.URL = "https://" + d.URL
+	if _, err := d.Encode(); err == nil {
+		t.Error("DynamicCode.Encode() with the scheme error = nil, want error")
+	}
+
+	c, err := (&pix.DynamicCode{URL: "pix.example.com/qr/v2/1", MerchantName: "Fulano de Tal", MerchantCity: "BRASILIA"}).Code()
+	if err != nil {
+		t.Fatalf("DynamicCode.Code() error = %v", err)
+	}
+	c.AdditionalDataFieldTemplate.ReferenceLabel = "PEDIDO1234"
+	var fe *mpm.FieldError
+	if _, err := pix.Encode(c); !errors.As(err, &fe) || fe.ID != "62.05" {
+		t.Errorf("Encode() of dynamic BR Code with txid error = %#v, want ID 62.05", err)
+	}
+}
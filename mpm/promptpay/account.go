@@ -0,0 +1,188 @@
+package promptpay
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+const (
+	creditTransferAID = "A000000677010111"
+	billPaymentAID    = "A000000677010112"
+
+	creditTransferID = "29"
+	billPaymentID    = "30"
+
+	mobileNumberLength = 13
+	nationalIDLength   = 13
+	eWalletIDLength    = 15
+	billerIDLength     = 15
+	referenceMaxLength = 20
+)
+
+// Account represents a PromptPay Merchant Account Information template, which is either
+// *CreditTransfer or *BillPayment.
+type Account interface {
+	template() *mpm.MerchantAccountInformationTemplate
+}
+
+// CreditTransfer represents a parsed PromptPay credit transfer template. Exactly one of the proxies is set.
+type CreditTransfer struct {
+	MobileNumber string // normalised by NormalizeMobileNumber, e.g. "0066812345678"
+	NationalID   string // national ID or tax ID of 13 digits
+	EWalletID    string // e-wallet ID of 15 digits
+}
+
+var (
+	errMissingCreditTransfer = errors.New("missing credit transfer")
+	errMissingBillPayment    = errors.New("missing bill payment")
+)
+
+// String returns the accumulated string.
+func (t *CreditTransfer) String() string {
+	v, _ := t.template().Tokenize()
+	return v
+}
+
+func (t *CreditTransfer) template() *mpm.MerchantAccountInformationTemplate {
+	m := &mpm.MerchantAccountInformationTemplate{ID: creditTransferID, GloballyUniqueIdentifier: creditTransferAID}
+	for _, v := range []struct{ tag, value string }{
+		{"01", t.MobileNumber},
+		{"02", t.NationalID},
+		{"03", t.EWalletID},
+	} {
+		if v.value != "" {
+			m.Data = append(m.Data, tlv.TLV{Tag: v.tag, Value: v.value})
+		}
+	}
+	return m
+}
+
+func validateCreditTransferFormat(t *CreditTransfer) error {
+	var n int
+	for _, v := range []string{t.MobileNumber, t.NationalID, t.EWalletID} {
+		if v != "" {
+			n++
+		}
+	}
+	if n != 1 {
+		return errors.New("credit transfer should have exactly one of mobile number, national ID and e-wallet ID")
+	}
+	switch {
+	case t.MobileNumber != "":
+		if len(t.MobileNumber) != mobileNumberLength || !strings.HasPrefix(t.MobileNumber, mobileNumberPrefix) || !mpm.IsNumeric(t.MobileNumber) {
+			return fmt.Errorf("mobile number should be %d digits starting with %s", mobileNumberLength, mobileNumberPrefix)
+		}
+	case t.NationalID != "":
+		if len(t.NationalID) != nationalIDLength || !mpm.IsNumeric(t.NationalID) {
+			return fmt.Errorf("national ID should be %d digits", nationalIDLength)
+		}
+	default:
+		if len(t.EWalletID) != eWalletIDLength || !mpm.IsNumeric(t.EWalletID) {
+			return fmt.Errorf("e-wallet ID should be %d digits", eWalletIDLength)
+		}
+	}
+	return nil
+}
+
+// BillPayment represents a parsed PromptPay bill payment template.
+type BillPayment struct {
+	BillerID   string // tax ID of 13 digits followed by a suffix of 2 digits
+	Reference1 string
+	Reference2 string // optional
+}
+
+// String returns the accumulated string.
+func (b *BillPayment) String() string {
+	v, _ := b.template().Tokenize()
+	return v
+}
+
+func (b *BillPayment) template() *mpm.MerchantAccountInformationTemplate {
+	m := &mpm.MerchantAccountInformationTemplate{ID: billPaymentID, GloballyUniqueIdentifier: billPaymentAID}
+	for _, v := range []struct{ tag, value string }{
+		{"01", b.BillerID},
+		{"02", b.Reference1},
+		{"03", b.Reference2},
+	} {
+		if v.value != "" {
+			m.Data = append(m.Data, tlv.TLV{Tag: v.tag, Value: v.value})
+		}
+	}
+	return m
+}
+
+func validateBillPaymentFormat(b *BillPayment) error {
+	if len(b.BillerID) != billerIDLength || !mpm.IsNumeric(b.BillerID) {
+		return fmt.Errorf("biller ID should be %d digits", billerIDLength)
+	}
+	if b.Reference1 == "" || referenceMaxLength < len(b.Reference1) {
+		return fmt.Errorf("len(Reference1) should be between 1 and %d", referenceMaxLength)
+	}
+	if referenceMaxLength < len(b.Reference2) {
+		return fmt.Errorf("len(Reference2) should be at most %d", referenceMaxLength)
+	}
+	return nil
+}
+
+func init() {
+	mpm.RegisterScheme(mpm.Scheme{
+		Name:  "PromptPay",
+		Match: mpm.MatchGUID(creditTransferAID),
+		Parse: parseCreditTransfer,
+	})
+	mpm.RegisterScheme(mpm.Scheme{
+		Name:  "PromptPay Bill Payment",
+		Match: mpm.MatchGUID(billPaymentAID),
+		Parse: parseBillPayment,
+	})
+}
+
+func parseCreditTransfer(m *mpm.MerchantAccountInformationTemplate) (interface{}, error) {
+	var t CreditTransfer
+	t.MobileNumber, _ = m.Value("01")
+	t.NationalID, _ = m.Value("02")
+	t.EWalletID, _ = m.Value("03")
+	if err := validateCreditTransferFormat(&t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func parseBillPayment(m *mpm.MerchantAccountInformationTemplate) (interface{}, error) {
+	var b BillPayment
+	b.BillerID, _ = m.Value("01")
+	b.Reference1, _ = m.Value("02")
+	b.Reference2, _ = m.Value("03")
+	if err := validateBillPaymentFormat(&b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// ParseCreditTransfer validates and parses given *mpm.Code as PromptPay credit transfer.
+func ParseCreditTransfer(c *mpm.Code) (*CreditTransfer, error) {
+	v, err := c.AccountInformation(creditTransferAID)
+	if errors.Is(err, mpm.ErrAccountInformationNotFound) {
+		return nil, errMissingCreditTransfer
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v.(*CreditTransfer), nil
+}
+
+// ParseBillPayment validates and parses given *mpm.Code as PromptPay bill payment.
+func ParseBillPayment(c *mpm.Code) (*BillPayment, error) {
+	v, err := c.AccountInformation(billPaymentAID)
+	if errors.Is(err, mpm.ErrAccountInformationNotFound) {
+		return nil, errMissingBillPayment
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v.(*BillPayment), nil
+}
@@ -0,0 +1,30 @@
+package promptpay_test
+
+import (
+	"fmt"
+	"log"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/mpm/promptpay"
+)
+
+func ExampleBuild() {
+	number, err := promptpay.NormalizeMobileNumber("081-234-5678")
+	if err != nil {
+		log.Fatal(err)
+	}
+	c, err := promptpay.Build(&promptpay.CreditTransfer{MobileNumber: number}, mpm.Amount{MinorUnits: 1000000, Exponent: 2})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	buf, err := promptpay.Encode(c)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(string(buf))
+
+	// Output:
+	// 00020101021229370016A000000677010111011300668123456785303764540810000.005802TH6304913E
+}
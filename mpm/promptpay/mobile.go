@@ -0,0 +1,34 @@
+package promptpay
+
+import (
+	"fmt"
+	"strings"
+
+	"go.mercari.io/go-emv-code/mpm"
+)
+
+const (
+	mobileNumberPrefix  = "0066"
+	subscriberNumberLen = 9
+)
+
+// NormalizeMobileNumber converts a Thai mobile number into the 13-digit form of PromptPay,
+// e.g. "081-234-5678" and "+66 81 234 5678" into "0066812345678".
+// Spaces and hyphens are ignored.
+func NormalizeMobileNumber(number string) (string, error) {
+	s := strings.NewReplacer(" ", "", "-", "").Replace(number)
+	switch {
+	case strings.HasPrefix(s, "+66"):
+		s = s[len("+66"):]
+	case strings.HasPrefix(s, mobileNumberPrefix):
+		s = s[len(mobileNumberPrefix):]
+	case strings.HasPrefix(s, "66") && len(s) == len("66")+subscriberNumberLen:
+		s = s[len("66"):]
+	case strings.HasPrefix(s, "0"):
+		s = s[len("0"):]
+	}
+	if len(s) != subscriberNumberLen || !mpm.IsNumeric(s) {
+		return "", fmt.Errorf("promptpay: invalid mobile number %q", number)
+	}
+	return mobileNumberPrefix + s, nil
+}
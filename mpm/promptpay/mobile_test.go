@@ -0,0 +1,37 @@
+package promptpay_test
+
+import (
+	"testing"
+
+	"go.mercari.io/go-emv-code/mpm/promptpay"
+)
+
+func TestNormalizeMobileNumber(t *testing.T) {
+	tests := []struct {
+		number  string
+		want    string
+		wantErr bool
+	}{
+		{number: "0812345678", want: "0066812345678"},
+		{number: "081-234-5678", want: "0066812345678"},
+		{number: "+66 81 234 5678", want: "0066812345678"},
+		{number: "66812345678", want: "0066812345678"},
+		{number: "0066812345678", want: "0066812345678"},
+		{number: "081234567", wantErr: true},
+		{number: "+6591234567", wantErr: true},
+		{number: "08123456789", wantErr: true},
+		{number: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.number, func(t *testing.T) {
+			got, err := promptpay.NormalizeMobileNumber(tt.number)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeMobileNumber() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeMobileNumber() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Package promptpay implements encoding and decoding of PromptPay, the Thai QR Payment standard.
+*/
+package promptpay
+
+import (
+	"errors"
+	"fmt"
+
+	"go.mercari.io/go-emv-code/mpm"
+)
+
+var validators = []mpm.ValidatorFunc{
+	validateConformance,
+	validateAccount,
+	mpm.CountryCodeValidator(countryCode),
+	mpm.TransactionCurrencyValidator(transactionCurrency),
+}
+
+// Decode decodes payload and validates as PromptPay.
+func Decode(payload []byte) (*mpm.Code, error) {
+	c, err := (&mpm.Decoder{SkipConformance: true}).Decode(payload, validators...)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Encode encodes to EMV Payment Code payload.
+func Encode(c *mpm.Code) ([]byte, error) {
+	return (&mpm.Encoder{SkipConformance: true}).Encode(c, validators...)
+}
+
+// Build returns a dynamic PromptPay Code which transfers amount to account.
+func Build(account Account, amount mpm.Amount) (*mpm.Code, error) {
+	if amount.Exponent != transactionCurrencyExponent {
+		return nil, fmt.Errorf("promptpay: exponent of amount %s should be %d", amount, transactionCurrencyExponent)
+	}
+	t, err := account.template().TLV()
+	if err != nil {
+		return nil, err
+	}
+	c := &mpm.Code{
+		PayloadFormatIndicator:  "01",
+		PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+		TransactionCurrency:     transactionCurrency,
+		CountryCode:             countryCode,
+	}
+	c.MerchantAccountInformation = append(c.MerchantAccountInformation, t)
+	c, err = c.WithAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+	for _, vf := range validators {
+		if err := vf(c); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// optionalIDs are the data objects PromptPay omits though EMV MPM requires them.
+var optionalIDs = map[string]struct{}{
+	"52": {}, // MerchantCategoryCode
+	"59": {}, // MerchantName
+	"60": {}, // MerchantCity
+}
+
+// validateConformance runs mpm.ConformanceValidators except for the presence of optionalIDs.
+func validateConformance(c *mpm.Code) error {
+	for _, vf := range mpm.ConformanceValidators() {
+		err := vf(c)
+		var fe *mpm.FieldError
+		if errors.As(err, &fe) && fe.Reason == mpm.ReasonMissing {
+			if _, ok := optionalIDs[fe.ID]; ok {
+				continue
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateAccount(c *mpm.Code) error {
+	_, err := ParseCreditTransfer(c)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, errMissingCreditTransfer) {
+		return err
+	}
+	_, err = ParseBillPayment(c)
+	if errors.Is(err, errMissingBillPayment) {
+		return mpm.NewFieldError("", "MerchantAccountInformation", "", mpm.ReasonMissing, "promptpay: missing credit transfer or bill payment")
+	}
+	return err
+}
+
+const (
+	countryCode                 = "TH"
+	transactionCurrency         = "764"
+	transactionCurrencyExponent = 2
+)
@@ -0,0 +1,237 @@
+package promptpay_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/mpm/promptpay"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name               string
+		payload            string
+		wantCreditTransfer *promptpay.CreditTransfer
+		wantBillPayment    *promptpay.BillPayment
+		wantErr            bool
+	}{
+		{
+			name:               "pass: credit transfer to mobile number",
+			payload:            "00020101021229370016A000000677010111011300668123456785303764540810000.005802TH6304913E",
+			wantCreditTransfer: &promptpay.CreditTransfer{MobileNumber: "0066812345678"},
+		},
+		{
+			name:               "pass: static credit transfer to national ID",
+			payload:            "00020101021129370016A0000006770101110213123456789012353037645802TH630433FC",
+			wantCreditTransfer: &promptpay.CreditTransfer{NationalID: "1234567890123"},
+		},
+		{
+			name:            "pass: bill payment",
+			payload:         "00020101021230600016A000000677010112011501234567890123402060000010307INV12345303764540510.505802TH630457BF",
+			wantBillPayment: &promptpay.BillPayment{BillerID: "012345678901234", Reference1: "000001", Reference2: "INV1234"},
+		},
+		{
+			name:    "err: JPQR",
+			payload: "0002016003xxx01021129300012D156000000000510A93FO3230Q31280012D1560000000103081234567826680019jp.or.paymentsjapan01130000000000001020400010306000001040600000153033925903xxx64180002JA0108メルペイ カフェ520441115802JP610710661436304DEE9",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := promptpay.Decode([]byte(tt.payload))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got, _ := promptpay.ParseCreditTransfer(c); !reflect.DeepEqual(got, tt.wantCreditTransfer) {
+				t.Errorf("ParseCreditTransfer() = %+v, want %+v", got, tt.wantCreditTransfer)
+			}
+			if got, _ := promptpay.ParseBillPayment(c); !reflect.DeepEqual(got, tt.wantBillPayment) {
+				t.Errorf("ParseBillPayment() = %+v, want %+v", got, tt.wantBillPayment)
+			}
+			buf, err := promptpay.Encode(c)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if string(buf) != tt.payload {
+				t.Errorf("Encode() = %s, want %s", buf, tt.payload)
+			}
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name    string
+		account promptpay.Account
+		amount  mpm.Amount
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "pass: credit transfer",
+			account: &promptpay.CreditTransfer{MobileNumber: "0066812345678"},
+			amount:  mpm.Amount{MinorUnits: 1000000, Exponent: 2},
+			want:    "00020101021229370016A000000677010111011300668123456785303764540810000.005802TH6304913E",
+		},
+		{
+			name:    "pass: bill payment",
+			account: &promptpay.BillPayment{BillerID: "012345678901234", Reference1: "000001", Reference2: "INV1234"},
+			amount:  mpm.Amount{MinorUnits: 1050, Exponent: 2},
+			want:    "00020101021230600016A000000677010112011501234567890123402060000010307INV12345303764540510.505802TH630457BF",
+		},
+		{
+			name:    "err: amount of another exponent",
+			account: &promptpay.CreditTransfer{MobileNumber: "0066812345678"},
+			amount:  mpm.Amount{MinorUnits: 100, Exponent: 0},
+			wantErr: true,
+		},
+		{
+			name:    "err: credit transfer to two proxies",
+			account: &promptpay.CreditTransfer{MobileNumber: "0066812345678", NationalID: "1234567890123"},
+			amount:  mpm.Amount{MinorUnits: 100, Exponent: 2},
+			wantErr: true,
+		},
+		{
+			name:    "err: mobile number is not normalised",
+			account: &promptpay.CreditTransfer{MobileNumber: "0812345678"},
+			amount:  mpm.Amount{MinorUnits: 100, Exponent: 2},
+			wantErr: true,
+		},
+		{
+			name:    "err: bill payment without Reference1",
+			account: &promptpay.BillPayment{BillerID: "012345678901234"},
+			amount:  mpm.Amount{MinorUnits: 100, Exponent: 2},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := promptpay.Build(tt.account, tt.amount)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Build() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			buf, err := promptpay.Encode(c)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if string(buf) != tt.want {
+				t.Errorf("Encode() = %s, want %s", buf, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name       string
+		give       *mpm.Code
+		wantErr    bool
+		wantID     string
+		wantReason mpm.Reason
+	}{
+		{
+			name: "pass",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "29", Length: "39", Value: "0016A0000006770101110315123456789012345"},
+				},
+				TransactionCurrency: "764",
+				TransactionAmount:   mpm.NullString{String: "1.00", Valid: true},
+				CountryCode:         "TH",
+			},
+		},
+		{
+			name: "err: CountryCode is not TH",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "29", Length: "39", Value: "0016A0000006770101110315123456789012345"},
+				},
+				TransactionCurrency: "764",
+				TransactionAmount:   mpm.NullString{String: "1.00", Valid: true},
+				CountryCode:         "JP",
+			},
+			wantErr:    true,
+			wantID:     "58",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: TransactionCurrency is not THB",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "29", Length: "39", Value: "0016A0000006770101110315123456789012345"},
+				},
+				TransactionCurrency: "840",
+				TransactionAmount:   mpm.NullString{String: "1.00", Valid: true},
+				CountryCode:         "TH",
+			},
+			wantErr:    true,
+			wantID:     "53",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: missing account",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "5123456789012345"},
+				},
+				TransactionCurrency: "764",
+				TransactionAmount:   mpm.NullString{String: "1.00", Valid: true},
+				CountryCode:         "TH",
+			},
+			wantErr:    true,
+			wantID:     "",
+			wantReason: mpm.ReasonMissing,
+		},
+		{
+			name: "err: e-wallet ID is not 15 digits",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "29", Length: "38", Value: "0016A000000677010111031412345678901234"},
+				},
+				TransactionCurrency: "764",
+				TransactionAmount:   mpm.NullString{String: "1.00", Valid: true},
+				CountryCode:         "TH",
+			},
+			wantErr:    true,
+			wantID:     "29",
+			wantReason: mpm.ReasonValue,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := promptpay.Encode(tt.give)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			var fe *mpm.FieldError
+			if !errors.As(err, &fe) || fe.ID != tt.wantID || fe.Reason != tt.wantReason {
+				t.Errorf("Encode() error = %#v, want ID %q of %s", err, tt.wantID, tt.wantReason)
+			}
+		})
+	}
+}
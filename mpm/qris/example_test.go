@@ -0,0 +1,42 @@
+package qris_test
+
+import (
+	"fmt"
+	"log"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/mpm/qris"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+func ExampleWithAmount() {
+	national, err := (&qris.NationalMerchant{NMID: "ID1020021181745", Criteria: qris.MerchantCriteriaMicro}).TLV()
+	if err != nil {
+		log.Fatal(err)
+	}
+	c := mpm.Code{
+		PayloadFormatIndicator:     "01",
+		PointOfInitiationMethod:    mpm.PointOfInitiationMethodStatic,
+		MerchantAccountInformation: []tlv.TLV{national},
+		MerchantCategoryCode:       "5812",
+		TransactionCurrency:        "360",
+		CountryCode:                "ID",
+		MerchantName:               "MERPAY CAFE",
+		MerchantCity:               "JAKARTA",
+		PostalCode:                 "10110",
+	}
+
+	d, err := qris.WithAmount(&c, 15000)
+	if err != nil {
+		log.Fatal(err)
+	}
+	buf, err := qris.Encode(d)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(string(buf))
+
+	// Output:
+	// 00020101021251440014ID.CO.QRIS.WWW0215ID10200211817450303UMI5204581253033605405150005802ID5911MERPAY CAFE6007JAKARTA6105101106304D0FE
+}
@@ -0,0 +1,189 @@
+package qris
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+const (
+	nationalMerchantGUID = "ID.CO.QRIS.WWW"
+	nationalMerchantID   = "51"
+
+	merchantAccountIDFrom = 26
+	merchantAccountIDTo   = 45
+
+	panMinLength        = 16
+	panMaxLength        = 19
+	merchantIDMaxLength = 15
+)
+
+// MerchantCriteria represents the business scale of the merchant.
+type MerchantCriteria string
+
+const (
+	MerchantCriteriaMicro  MerchantCriteria = "UMI" // usaha mikro
+	MerchantCriteriaSmall  MerchantCriteria = "UKE" // usaha kecil
+	MerchantCriteriaMedium MerchantCriteria = "UME" // usaha menengah
+	MerchantCriteriaLarge  MerchantCriteria = "UBE" // usaha besar
+)
+
+func (m MerchantCriteria) valid() bool {
+	switch m {
+	case MerchantCriteriaMicro, MerchantCriteriaSmall, MerchantCriteriaMedium, MerchantCriteriaLarge:
+		return true
+	}
+	return false
+}
+
+var nmidPattern = regexp.MustCompile(`^ID[0-9]{13}$`)
+
+// NationalMerchant represents a parsed QRIS national merchant template (ID 51).
+type NationalMerchant struct {
+	NMID     string // National Merchant ID, e.g. "ID1020021181745"
+	Criteria MerchantCriteria
+}
+
+// String returns the accumulated string.
+func (n *NationalMerchant) String() string {
+	v, _ := n.template().Tokenize()
+	return v
+}
+
+func (n *NationalMerchant) template() *mpm.MerchantAccountInformationTemplate {
+	return &mpm.MerchantAccountInformationTemplate{
+		ID:                       nationalMerchantID,
+		GloballyUniqueIdentifier: nationalMerchantGUID,
+		Data:                     []tlv.TLV{{Tag: "02", Value: n.NMID}, {Tag: "03", Value: string(n.Criteria)}},
+	}
+}
+
+// TLV returns the template as a tlv.TLV of mpm.Code.MerchantAccountInformation.
+func (n *NationalMerchant) TLV() (tlv.TLV, error) {
+	return n.template().TLV()
+}
+
+// validateNationalMerchantFormat validates n of the Merchant Account Information template of id.
+func validateNationalMerchantFormat(id string, n *NationalMerchant) error {
+	if !nmidPattern.MatchString(n.NMID) {
+		return templateError(id, n.NMID, mpm.ReasonValue, "NMID should be ID followed by 13 digits")
+	}
+	if !n.Criteria.valid() {
+		return templateError(id, string(n.Criteria), mpm.ReasonValue, fmt.Sprintf("merchant criteria should be one of %s, %s, %s and %s", MerchantCriteriaMicro, MerchantCriteriaSmall, MerchantCriteriaMedium, MerchantCriteriaLarge))
+	}
+	return nil
+}
+
+func templateError(id, value string, reason mpm.Reason, msg string) error {
+	return mpm.NewFieldError(id, "MerchantAccountInformation", value, reason, "qris: "+msg)
+}
+
+func init() {
+	mpm.RegisterScheme(mpm.Scheme{
+		Name:  "QRIS",
+		Match: mpm.MatchGUID(nationalMerchantGUID),
+		Parse: parseNationalMerchant,
+	})
+}
+
+func parseNationalMerchant(m *mpm.MerchantAccountInformationTemplate) (interface{}, error) {
+	var n NationalMerchant
+	n.NMID, _ = m.Value("02")
+	v, _ := m.Value("03")
+	n.Criteria = MerchantCriteria(v)
+	if err := validateNationalMerchantFormat(m.ID, &n); err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+var errMissingNationalMerchant = errors.New("missing QRIS national merchant")
+
+// ParseNationalMerchant validates and parses given *mpm.Code as QRIS national merchant.
+// If the template is malformed, it returns *mpm.FieldError of the ID of the template.
+func ParseNationalMerchant(c *mpm.Code) (*NationalMerchant, error) {
+	v, err := c.AccountInformation(nationalMerchantGUID)
+	if errors.Is(err, mpm.ErrAccountInformationNotFound) {
+		return nil, errMissingNationalMerchant
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v.(*NationalMerchant), nil
+}
+
+// MerchantAccount represents a parsed merchant account template of an acquirer or issuer (ID 26–45).
+type MerchantAccount struct {
+	ID         string
+	GUID       string // reverse domain name of the acquirer or issuer
+	PAN        string // Merchant PAN, which starts with National Numbering System of the institution
+	MerchantID string
+	Criteria   MerchantCriteria
+}
+
+// TLV returns the template as a tlv.TLV of mpm.Code.MerchantAccountInformation.
+func (a *MerchantAccount) TLV() (tlv.TLV, error) {
+	m := mpm.MerchantAccountInformationTemplate{
+		ID:                       a.ID,
+		GloballyUniqueIdentifier: a.GUID,
+		Data: []tlv.TLV{
+			{Tag: "01", Value: a.PAN},
+			{Tag: "02", Value: a.MerchantID},
+			{Tag: "03", Value: string(a.Criteria)},
+		},
+	}
+	return m.TLV()
+}
+
+func validateMerchantAccountFormat(a *MerchantAccount) error {
+	if a.GUID == "" {
+		return templateError(a.ID, "", mpm.ReasonMissing, fmt.Sprintf("GUID of %s should be represented", a.ID))
+	}
+	if len(a.PAN) < panMinLength || panMaxLength < len(a.PAN) {
+		return templateError(a.ID, a.PAN, mpm.ReasonLength, fmt.Sprintf("PAN of %s should be between %d and %d digits", a.ID, panMinLength, panMaxLength))
+	}
+	if !mpm.IsNumeric(a.PAN) {
+		return templateError(a.ID, a.PAN, mpm.ReasonValue, fmt.Sprintf("PAN of %s should be between %d and %d digits", a.ID, panMinLength, panMaxLength))
+	}
+	if a.MerchantID == "" || merchantIDMaxLength < len(a.MerchantID) {
+		return templateError(a.ID, a.MerchantID, mpm.ReasonLength, fmt.Sprintf("len(MerchantID) of %s should be between 1 and %d", a.ID, merchantIDMaxLength))
+	}
+	if !a.Criteria.valid() {
+		return templateError(a.ID, string(a.Criteria), mpm.ReasonValue, fmt.Sprintf("merchant criteria of %s should be one of %s, %s, %s and %s", a.ID, MerchantCriteriaMicro, MerchantCriteriaSmall, MerchantCriteriaMedium, MerchantCriteriaLarge))
+	}
+	return nil
+}
+
+func isMerchantAccountID(id string) bool {
+	n, err := strconv.Atoi(id)
+	return err == nil && merchantAccountIDFrom <= n && n <= merchantAccountIDTo
+}
+
+// ParseMerchantAccounts validates and parses the merchant account templates (ID 26–45) of given *mpm.Code
+// in order of appearance. If a template is malformed, it returns *mpm.FieldError of the ID of the template.
+func ParseMerchantAccounts(c *mpm.Code) ([]MerchantAccount, error) {
+	templates, err := c.MerchantAccountInformationTemplates()
+	if err != nil {
+		return nil, err
+	}
+	var s []MerchantAccount
+	for _, m := range templates {
+		if !isMerchantAccountID(m.ID) {
+			continue
+		}
+		a := MerchantAccount{ID: m.ID, GUID: m.GloballyUniqueIdentifier}
+		a.PAN, _ = m.Value("01")
+		a.MerchantID, _ = m.Value("02")
+		v, _ := m.Value("03")
+		a.Criteria = MerchantCriteria(v)
+		if err := validateMerchantAccountFormat(&a); err != nil {
+			return nil, err
+		}
+		s = append(s, a)
+	}
+	return s, nil
+}
@@ -0,0 +1,118 @@
+/*
+Package qris implements encoding and decoding of QRIS, the Quick Response Code Indonesian Standard.
+*/
+package qris
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.mercari.io/go-emv-code/mpm"
+)
+
+const (
+	countryCode         = "ID"
+	transactionCurrency = "360"
+)
+
+var validators = []mpm.ValidatorFunc{
+	validateNationalMerchant,
+	validateMerchantAccounts,
+	mpm.CountryCodeValidator(countryCode),
+	mpm.TransactionCurrencyValidator(transactionCurrency),
+	validatePostalCode,
+	validateAmounts,
+}
+
+// Decode decodes payload and validates as QRIS.
+func Decode(payload []byte) (*mpm.Code, error) {
+	c, err := mpm.Decode(payload, validators...)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Encode encodes to EMV Payment Code payload.
+func Encode(c *mpm.Code) ([]byte, error) {
+	return mpm.Encode(c, validators...)
+}
+
+// WithAmount returns a copy of the static QRIS c as a dynamic one of amount in rupiah.
+func WithAmount(c *mpm.Code, rupiah int64) (*mpm.Code, error) {
+	d, err := c.WithAmount(mpm.Amount{MinorUnits: rupiah * 100, Exponent: 2})
+	if err != nil {
+		return nil, err
+	}
+	// QRIS represents amounts in whole rupiah without decimals.
+	d.TransactionAmount.String = strconv.FormatInt(rupiah, 10)
+	for _, vf := range validators {
+		if err := vf(d); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func validateNationalMerchant(c *mpm.Code) error {
+	_, err := ParseNationalMerchant(c)
+	if errors.Is(err, errMissingNationalMerchant) {
+		return mpm.NewFieldError(nationalMerchantID, "MerchantAccountInformation", "", mpm.ReasonMissing, fmt.Sprintf("qris: %s", err))
+	}
+	return err
+}
+
+func validateMerchantAccounts(c *mpm.Code) error {
+	_, err := ParseMerchantAccounts(c)
+	return err
+}
+
+func validatePostalCode(c *mpm.Code) error {
+	if c.PostalCode == "" {
+		return mpm.NewFieldError("61", "PostalCode", c.PostalCode, mpm.ReasonMissing, "qris: PostalCode should be represented")
+	}
+	return nil
+}
+
+// validateAmounts checks Value of Convenience Fee Fixed and Percentage are represented as Tip or Convenience Indicator
+// requires, and Transaction Amount and Value of Convenience Fee Fixed are in whole rupiah.
+func validateAmounts(c *mpm.Code) error {
+	fixed := c.TipOrConvenienceIndicator == mpm.TipOrConvenienceIndicatorFixed
+	percentage := c.TipOrConvenienceIndicator == mpm.TipOrConvenienceIndicatorPercentage
+	for _, v := range []struct {
+		id, field string
+		value     mpm.NullString
+		required  bool
+	}{
+		{"56", "ValueOfConvenienceFeeFixed", c.ValueOfConvenienceFeeFixed, fixed},
+		{"57", "ValueOfConvenienceFeePercentage", c.ValueOfConvenienceFeePercentage, percentage},
+	} {
+		switch {
+		case v.required && !v.value.Valid:
+			return mpm.NewFieldError(v.id, v.field, "", mpm.ReasonMissing, fmt.Sprintf("qris: %s should be represented if TipOrConvenienceIndicator is %s", v.field, c.TipOrConvenienceIndicator))
+		case !v.required && v.value.Valid:
+			return mpm.NewFieldError(v.id, v.field, v.value.String, mpm.ReasonUnexpected, fmt.Sprintf("qris: %s should not be represented if TipOrConvenienceIndicator is %q", v.field, c.TipOrConvenienceIndicator))
+		}
+	}
+	for _, v := range []struct {
+		id, field string
+		value     mpm.NullString
+	}{
+		{"54", "TransactionAmount", c.TransactionAmount},
+		{"56", "ValueOfConvenienceFeeFixed", c.ValueOfConvenienceFeeFixed},
+	} {
+		if v.value.Valid && !isWholeRupiah(v.value.String) {
+			return mpm.NewFieldError(v.id, v.field, v.value.String, mpm.ReasonValue, fmt.Sprintf("qris: %s should be in whole rupiah", v.field))
+		}
+	}
+	return nil
+}
+
+func isWholeRupiah(s string) bool {
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return strings.Trim(s[i+1:], "0") == ""
+	}
+	return true
+}
@@ -0,0 +1,418 @@
+package qris_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/mpm/qris"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	tests := []struct {
+		name       string
+		give       *mpm.Code
+		wantErr    bool
+		wantID     string
+		wantReason mpm.Reason
+	}{
+		{
+			name: "pass",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "63", Value: "0017ID.CO.EXAMPLE.WWW011893600014000001234502090000123450303UMI"},
+					{Tag: "51", Length: "44", Value: "0014ID.CO.QRIS.WWW0215ID10200211817450303UMI"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "360",
+				CountryCode:          "ID",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "JAKARTA",
+				PostalCode:           "10110",
+			},
+		},
+		{
+			name: "pass: amount with zero decimals",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "63", Value: "0017ID.CO.EXAMPLE.WWW011893600014000001234502090000123450303UMI"},
+					{Tag: "51", Length: "44", Value: "0014ID.CO.QRIS.WWW0215ID10200211817450303UMI"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "360",
+				TransactionAmount:    mpm.NullString{String: "15000.00", Valid: true},
+				CountryCode:          "ID",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "JAKARTA",
+				PostalCode:           "10110",
+			},
+		},
+		{
+			name: "err: missing national merchant",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "63", Value: "0017ID.CO.EXAMPLE.WWW011893600014000001234502090000123450303UMI"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "360",
+				CountryCode:          "ID",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "JAKARTA",
+				PostalCode:           "10110",
+			},
+			wantErr:    true,
+			wantID:     "51",
+			wantReason: mpm.ReasonMissing,
+		},
+		{
+			name: "err: invalid national merchant",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "63", Value: "0017ID.CO.EXAMPLE.WWW011893600014000001234502090000123450303UMI"},
+					{Tag: "51", Length: "44", Value: "0014ID.CO.QRIS.WWW0215XX10200211817450303UMI"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "360",
+				CountryCode:          "ID",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "JAKARTA",
+				PostalCode:           "10110",
+			},
+			wantErr:    true,
+			wantID:     "51",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: invalid merchant account",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "27", Length: "29", Value: "0017ID.CO.EXAMPLE.WWW01041234"},
+					{Tag: "51", Length: "44", Value: "0014ID.CO.QRIS.WWW0215ID10200211817450303UMI"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "360",
+				CountryCode:          "ID",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "JAKARTA",
+				PostalCode:           "10110",
+			},
+			wantErr:    true,
+			wantID:     "27",
+			wantReason: mpm.ReasonLength,
+		},
+		{
+			name: "err: CountryCode is not ID",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "63", Value: "0017ID.CO.EXAMPLE.WWW011893600014000001234502090000123450303UMI"},
+					{Tag: "51", Length: "44", Value: "0014ID.CO.QRIS.WWW0215ID10200211817450303UMI"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "360",
+				CountryCode:          "SG",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "JAKARTA",
+				PostalCode:           "10110",
+			},
+			wantErr:    true,
+			wantID:     "58",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: TransactionCurrency is not IDR",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "63", Value: "0017ID.CO.EXAMPLE.WWW011893600014000001234502090000123450303UMI"},
+					{Tag: "51", Length: "44", Value: "0014ID.CO.QRIS.WWW0215ID10200211817450303UMI"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "702",
+				CountryCode:          "ID",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "JAKARTA",
+				PostalCode:           "10110",
+			},
+			wantErr:    true,
+			wantID:     "53",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: PostalCode is missing",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "63", Value: "0017ID.CO.EXAMPLE.WWW011893600014000001234502090000123450303UMI"},
+					{Tag: "51", Length: "44", Value: "0014ID.CO.QRIS.WWW0215ID10200211817450303UMI"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "360",
+				CountryCode:          "ID",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "JAKARTA",
+			},
+			wantErr:    true,
+			wantID:     "61",
+			wantReason: mpm.ReasonMissing,
+		},
+		{
+			name: "err: fixed fee is not in whole rupiah",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "63", Value: "0017ID.CO.EXAMPLE.WWW011893600014000001234502090000123450303UMI"},
+					{Tag: "51", Length: "44", Value: "0014ID.CO.QRIS.WWW0215ID10200211817450303UMI"},
+				},
+				MerchantCategoryCode:       "5812",
+				TransactionCurrency:        "360",
+				TipOrConvenienceIndicator:  mpm.TipOrConvenienceIndicatorFixed,
+				ValueOfConvenienceFeeFixed: mpm.NullString{String: "500.50", Valid: true},
+				CountryCode:                "ID",
+				MerchantName:               "MERPAY CAFE",
+				MerchantCity:               "JAKARTA",
+				PostalCode:                 "10110",
+			},
+			wantErr:    true,
+			wantID:     "56",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "pass: tip prompted",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "63", Value: "0017ID.CO.EXAMPLE.WWW011893600014000001234502090000123450303UMI"},
+					{Tag: "51", Length: "44", Value: "0014ID.CO.QRIS.WWW0215ID10200211817450303UMI"},
+				},
+				MerchantCategoryCode:      "5812",
+				TransactionCurrency:       "360",
+				TipOrConvenienceIndicator: mpm.TipOrConvenienceIndicatorPrompt,
+				CountryCode:               "ID",
+				MerchantName:              "MERPAY CAFE",
+				MerchantCity:              "JAKARTA",
+				PostalCode:                "10110",
+			},
+		},
+		{
+			name: "pass: fixed fee",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "63", Value: "0017ID.CO.EXAMPLE.WWW011893600014000001234502090000123450303UMI"},
+					{Tag: "51", Length: "44", Value: "0014ID.CO.QRIS.WWW0215ID10200211817450303UMI"},
+				},
+				MerchantCategoryCode:       "5812",
+				TransactionCurrency:        "360",
+				TipOrConvenienceIndicator:  mpm.TipOrConvenienceIndicatorFixed,
+				ValueOfConvenienceFeeFixed: mpm.NullString{String: "500", Valid: true},
+				CountryCode:                "ID",
+				MerchantName:               "MERPAY CAFE",
+				MerchantCity:               "JAKARTA",
+				PostalCode:                 "10110",
+			},
+		},
+		{
+			name: "pass: percentage fee",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "63", Value: "0017ID.CO.EXAMPLE.WWW011893600014000001234502090000123450303UMI"},
+					{Tag: "51", Length: "44", Value: "0014ID.CO.QRIS.WWW0215ID10200211817450303UMI"},
+				},
+				MerchantCategoryCode:            "5812",
+				TransactionCurrency:             "360",
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.5", Valid: true},
+				CountryCode:                     "ID",
+				MerchantName:                    "MERPAY CAFE",
+				MerchantCity:                    "JAKARTA",
+				PostalCode:                      "10110",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			buf, err := qris.Encode(tt.give)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				var fe *mpm.FieldError
+				if !errors.As(err, &fe) || fe.ID != tt.wantID || fe.Reason != tt.wantReason {
+					t.Errorf("Encode() error = %#v, want ID %s of %s", err, tt.wantID, tt.wantReason)
+				}
+				buf, _ = (&mpm.Encoder{SkipConformance: true}).Encode(tt.give)
+				_, err := qris.Decode(buf)
+				if !errors.As(err, &fe) || fe.ID != tt.wantID || fe.Reason != tt.wantReason {
+					t.Errorf("Decode() error = %#v, want ID %s of %s", err, tt.wantID, tt.wantReason)
+				}
+				return
+			}
+			got, err := qris.Decode(buf)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.MerchantAccountInformation, tt.give.MerchantAccountInformation) {
+				t.Errorf("Decode() = %+v, want %+v", got.MerchantAccountInformation, tt.give.MerchantAccountInformation)
+			}
+		})
+	}
+}
+
+func TestParseMerchantAccounts(t *testing.T) {
+	c := &mpm.Code{
+		PayloadFormatIndicator:  "01",
+		PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+		MerchantAccountInformation: []tlv.TLV{
+			{Tag: "26", Length: "63", Value: "0017ID.CO.EXAMPLE.WWW011893600014000001234502090000123450303UMI"},
+			{Tag: "51", Length: "44", Value: "0014ID.CO.QRIS.WWW0215ID10200211817450303UMI"},
+		},
+		MerchantCategoryCode: "5812",
+		TransactionCurrency:  "360",
+		CountryCode:          "ID",
+		MerchantName:         "MERPAY CAFE",
+		MerchantCity:         "JAKARTA",
+		PostalCode:           "10110",
+	}
+	got, err := qris.ParseMerchantAccounts(c)
+	if err != nil {
+		t.Fatalf("ParseMerchantAccounts() error = %v", err)
+	}
+	want := []qris.MerchantAccount{{ID: "26", GUID: "ID.CO.EXAMPLE.WWW", PAN: "936000140000012345", MerchantID: "000012345", Criteria: qris.MerchantCriteriaMicro}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseMerchantAccounts() = %+v, want %+v", got, want)
+	}
+
+	n, err := qris.ParseNationalMerchant(c)
+	if err != nil {
+		t.Fatalf("ParseNationalMerchant() error = %v", err)
+	}
+	if want := (&qris.NationalMerchant{NMID: "ID1020021181745", Criteria: qris.MerchantCriteriaMicro}); !reflect.DeepEqual(n, want) {
+		t.Errorf("ParseNationalMerchant() = %+v, want %+v", n, want)
+	}
+}
+
+func TestParseNationalMerchant(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "NMID without prefix", value: "0014ID.CO.QRIS.WWW02151020021181745000303UMI"},
+		{name: "NMID is too short", value: "0014ID.CO.QRIS.WWW0213ID10200211810303UMI"},
+		{name: "unknown criteria", value: "0014ID.CO.QRIS.WWW0215ID10200211817450303URE"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c := mpm.Code{MerchantAccountInformation: []tlv.TLV{{Tag: "51", Value: tt.value}}}
+			if _, err := qris.ParseNationalMerchant(&c); err == nil {
+				t.Error("ParseNationalMerchant() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestWithAmount(t *testing.T) {
+	c := &mpm.Code{
+		PayloadFormatIndicator:  "01",
+		PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+		MerchantAccountInformation: []tlv.TLV{
+			{Tag: "26", Length: "63", Value: "0017ID.CO.EXAMPLE.WWW011893600014000001234502090000123450303UMI"},
+			{Tag: "51", Length: "44", Value: "0014ID.CO.QRIS.WWW0215ID10200211817450303UMI"},
+		},
+		MerchantCategoryCode: "5812",
+		TransactionCurrency:  "360",
+		CountryCode:          "ID",
+		MerchantName:         "MERPAY CAFE",
+		MerchantCity:         "JAKARTA",
+		PostalCode:           "10110",
+	}
+	got, err := qris.WithAmount(c, 15000)
+	if err != nil {
+		t.Fatalf("WithAmount() error = %v", err)
+	}
+	if !got.IsDynamic() || got.TransactionAmount != (mpm.NullString{String: "15000", Valid: true}) {
+		t.Errorf("WithAmount() = %+v, want dynamic QRIS of 15000", got)
+	}
+	if _, err := qris.Encode(got); err != nil {
+		t.Errorf("Encode() error = %v", err)
+	}
+	if c.IsDynamic() {
+		t.Error("WithAmount() modified the static QRIS")
+	}
+}
+
+func TestWithAmount_tipOrConvenienceIndicator(t *testing.T) {
+	fixed := mpm.NullString{String: "500", Valid: true}
+	percentage := mpm.NullString{String: "3.5", Valid: true}
+	tests := []struct {
+		name       string
+		indicator  mpm.TipOrConvenienceIndicator
+		fixed      mpm.NullString
+		percentage mpm.NullString
+		wantErr    bool
+		wantID     string
+		wantReason mpm.Reason
+	}{
+		{name: "01", indicator: mpm.TipOrConvenienceIndicatorPrompt},
+		{name: "01 with fixed fee", indicator: mpm.TipOrConvenienceIndicatorPrompt, fixed: fixed, wantErr: true, wantID: "56", wantReason: mpm.ReasonUnexpected},
+		{name: "01 with percentage fee", indicator: mpm.TipOrConvenienceIndicatorPrompt, percentage: percentage, wantErr: true, wantID: "57", wantReason: mpm.ReasonUnexpected},
+		{name: "02", indicator: mpm.TipOrConvenienceIndicatorFixed, fixed: fixed},
+		{name: "02 without fixed fee", indicator: mpm.TipOrConvenienceIndicatorFixed, wantErr: true, wantID: "56", wantReason: mpm.ReasonMissing},
+		{name: "02 with percentage fee", indicator: mpm.TipOrConvenienceIndicatorFixed, fixed: fixed, percentage: percentage, wantErr: true, wantID: "57", wantReason: mpm.ReasonUnexpected},
+		{name: "03", indicator: mpm.TipOrConvenienceIndicatorPercentage, percentage: percentage},
+		{name: "03 without percentage fee", indicator: mpm.TipOrConvenienceIndicatorPercentage, wantErr: true, wantID: "57", wantReason: mpm.ReasonMissing},
+		{name: "03 with fixed fee", indicator: mpm.TipOrConvenienceIndicatorPercentage, fixed: fixed, percentage: percentage, wantErr: true, wantID: "56", wantReason: mpm.ReasonUnexpected},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c := &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "63", Value: "0017ID.CO.EXAMPLE.WWW011893600014000001234502090000123450303UMI"},
+					{Tag: "51", Length: "44", Value: "0014ID.CO.QRIS.WWW0215ID10200211817450303UMI"},
+				},
+				MerchantCategoryCode:            "5812",
+				TransactionCurrency:             "360",
+				TipOrConvenienceIndicator:       tt.indicator,
+				ValueOfConvenienceFeeFixed:      tt.fixed,
+				ValueOfConvenienceFeePercentage: tt.percentage,
+				CountryCode:                     "ID",
+				MerchantName:                    "MERPAY CAFE",
+				MerchantCity:                    "JAKARTA",
+				PostalCode:                      "10110",
+			}
+			_, err := qris.WithAmount(c, 15000)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WithAmount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var fe *mpm.FieldError
+			if tt.wantErr && (!errors.As(err, &fe) || fe.ID != tt.wantID || fe.Reason != tt.wantReason) {
+				t.Errorf("WithAmount() error = %#v, want ID %s of %s", err, tt.wantID, tt.wantReason)
+			}
+		})
+	}
+}
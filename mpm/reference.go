@@ -0,0 +1,64 @@
+package mpm
+
+import (
+	"fmt"
+
+	"go.mercari.io/go-emv-code/refdata"
+)
+
+// ReferenceDataValidators returns validators which check Transaction Currency, Country Code and
+// Merchant Category Code against the reference tables of package refdata.
+// They are not run by default; pass them to Decode, Encode or Builder.Build explicitly.
+func ReferenceDataValidators() []ValidatorFunc {
+	return []ValidatorFunc{
+		validateMerchantCategoryCodeReference,
+		validateTransactionCurrencyReference,
+		validateCountryCodeReference,
+	}
+}
+
+// CountryCodeValidator returns a validator which checks Country Code is code, e.g. "JP" of a domestic scheme.
+func CountryCodeValidator(code string) ValidatorFunc {
+	return func(c *Code) error {
+		if c.CountryCode == code {
+			return nil
+		}
+		return NewFieldError("58", "CountryCode", c.CountryCode, ReasonValue, fmt.Sprintf("mpm: CountryCode should be %s", code))
+	}
+}
+
+// TransactionCurrencyValidator returns a validator which checks Transaction Currency is currency, e.g. "392".
+func TransactionCurrencyValidator(currency string) ValidatorFunc {
+	return func(c *Code) error {
+		if c.TransactionCurrency == currency {
+			return nil
+		}
+		return NewFieldError("53", "TransactionCurrency", c.TransactionCurrency, ReasonValue, fmt.Sprintf("mpm: TransactionCurrency should be %s", currency))
+	}
+}
+
+// Currency returns the ISO 4217 currency of Transaction Currency.
+func (c *Code) Currency() (refdata.Currency, bool) {
+	return refdata.CurrencyByNumeric(c.TransactionCurrency)
+}
+
+func validateMerchantCategoryCodeReference(c *Code) error {
+	if _, ok := refdata.MerchantCategory(c.MerchantCategoryCode); !ok {
+		return NewFieldError("52", "MerchantCategoryCode", c.MerchantCategoryCode, ReasonValue, fmt.Sprintf("mpm: MerchantCategoryCode %s is not ISO 18245 code", c.MerchantCategoryCode))
+	}
+	return nil
+}
+
+func validateTransactionCurrencyReference(c *Code) error {
+	if _, ok := c.Currency(); !ok {
+		return NewFieldError("53", "TransactionCurrency", c.TransactionCurrency, ReasonValue, fmt.Sprintf("mpm: TransactionCurrency %s is not ISO 4217 numeric code", c.TransactionCurrency))
+	}
+	return nil
+}
+
+func validateCountryCodeReference(c *Code) error {
+	if !refdata.IsCountryCode(c.CountryCode) {
+		return NewFieldError("58", "CountryCode", c.CountryCode, ReasonValue, fmt.Sprintf("mpm: CountryCode %s is not ISO 3166-1 alpha-2 code", c.CountryCode))
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package mpm_test
+
+import (
+	"errors"
+	"testing"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/refdata"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+func TestReferenceDataValidators(t *testing.T) {
+	tests := []struct {
+		name       string
+		give       *mpm.Code
+		wantErr    bool
+		wantID     string
+		wantReason mpm.Reason
+	}{
+		{
+			name: "pass",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+		},
+		{
+			name: "err: unknown MerchantCategoryCode",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "0001",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "52",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: unknown TransactionCurrency",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "999",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "CN",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "53",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: unknown CountryCode",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodDynamic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "04", Length: "16", Value: "4000123456789012"},
+					{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				},
+				MerchantCategoryCode:            "4111",
+				TransactionCurrency:             "156",
+				TransactionAmount:               mpm.NullString{String: "23.72", Valid: true},
+				TipOrConvenienceIndicator:       mpm.TipOrConvenienceIndicatorPercentage,
+				ValueOfConvenienceFeePercentage: mpm.NullString{String: "3.00", Valid: true},
+				CountryCode:                     "XX",
+				MerchantName:                    "BEST TRANSPORT",
+				MerchantCity:                    "BEIJING",
+				PostalCode:                      "100000",
+				AdditionalDataFieldTemplate: mpm.AdditionalDataFieldTemplate{
+					StoreLabel:                    "1234",
+					AdditionalConsumerDataRequest: "ME",
+				},
+			},
+			wantErr:    true,
+			wantID:     "58",
+			wantReason: mpm.ReasonValue,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mpm.Encode(tt.give, mpm.ReferenceDataValidators()...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			var fe *mpm.FieldError
+			if !errors.As(err, &fe) || fe.ID != tt.wantID || fe.Reason != tt.wantReason {
+				t.Errorf("Encode() error = %#v, want ID %s of %s", err, tt.wantID, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestCode_Currency(t *testing.T) {
+	c := mpm.Code{TransactionCurrency: "392"}
+	got, ok := c.Currency()
+	if want := (refdata.Currency{Numeric: "392", Alpha: "JPY", Exponent: 0}); got != want || !ok {
+		t.Errorf("Code.Currency() = %+v, %v, want %+v, true", got, ok, want)
+	}
+}
+
+func TestCountryCodeValidator_TransactionCurrencyValidator(t *testing.T) {
+	tests := []struct {
+		name       string
+		give       mpm.Code
+		wantErr    bool
+		wantID     string
+		wantReason mpm.Reason
+	}{
+		{
+			name: "pass",
+			give: mpm.Code{CountryCode: "JP", TransactionCurrency: "392"},
+		},
+		{
+			name:       "err: CountryCode",
+			give:       mpm.Code{CountryCode: "jp", TransactionCurrency: "392"},
+			wantErr:    true,
+			wantID:     "58",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name:       "err: TransactionCurrency",
+			give:       mpm.Code{CountryCode: "JP", TransactionCurrency: "840"},
+			wantErr:    true,
+			wantID:     "53",
+			wantReason: mpm.ReasonValue,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			for _, vf := range []mpm.ValidatorFunc{mpm.CountryCodeValidator("JP"), mpm.TransactionCurrencyValidator("392")} {
+				if err = vf(&tt.give); err != nil {
+					break
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validator error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var e *mpm.FieldError
+			if tt.wantErr && (!errors.As(err, &e) || e.ID != tt.wantID || e.Reason != tt.wantReason) {
+				t.Errorf("validator error = %#v, want ID %s and Reason %s", err, tt.wantID, tt.wantReason)
+			}
+		})
+	}
+}
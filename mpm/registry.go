@@ -0,0 +1,164 @@
+package mpm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Scheme represents a payment system which owns Merchant Account Information templates identified by GUID.
+type Scheme struct {
+	// Name is the unique name of the scheme, e.g. "JPQR".
+	Name string
+	// Match reports whether the GloballyUniqueIdentifier belongs to the scheme.
+	Match func(guid string) bool
+	// Parse parses the template into the typed record of the scheme.
+	// It may return FieldError of the ID of the template to tell the reason, otherwise the reason is ReasonValue.
+	Parse func(*MerchantAccountInformationTemplate) (interface{}, error)
+}
+
+var (
+	schemesMu sync.RWMutex
+	schemes   = make(map[string]Scheme)
+)
+
+// RegisterScheme makes a payment system available to Code.AccountInformation and Code.Schemes.
+// If RegisterScheme is called twice with the same name or with nil functions, it panics.
+func RegisterScheme(s Scheme) {
+	schemesMu.Lock()
+	defer schemesMu.Unlock()
+	if s.Match == nil || s.Parse == nil {
+		panic("mpm: RegisterScheme Match and Parse should not be nil")
+	}
+	if _, dup := schemes[s.Name]; dup {
+		panic("mpm: RegisterScheme called twice for scheme " + s.Name)
+	}
+	schemes[s.Name] = s
+}
+
+// Schemes returns a sorted list of the names of the registered schemes.
+func Schemes() []string {
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
+	return sortedSchemeNames()
+}
+
+func lookupScheme(guid string) (Scheme, bool) {
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
+	for _, name := range sortedSchemeNames() {
+		if s := schemes[name]; s.Match(guid) {
+			return s, true
+		}
+	}
+	return Scheme{}, false
+}
+
+// sortedSchemeNames must be called with schemesMu held.
+func sortedSchemeNames() []string {
+	s := make([]string, 0, len(schemes))
+	for name := range schemes {
+		s = append(s, name)
+	}
+	sort.Strings(s)
+	return s
+}
+
+// MatchGUID returns a matcher for Scheme.Match which compares GUID with guids case-insensitively.
+func MatchGUID(guids ...string) func(string) bool {
+	return func(guid string) bool {
+		for _, v := range guids {
+			if strings.EqualFold(v, guid) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ErrSchemeNotFound represents no registered scheme matches the GUID.
+var ErrSchemeNotFound = errors.New("mpm: scheme not found")
+
+// ErrAccountInformationNotFound represents the Code has no Merchant Account Information template of the GUID.
+var ErrAccountInformationNotFound = errors.New("mpm: account information not found")
+
+// AccountInformation parses the Merchant Account Information template of guid by the registered scheme which matches guid.
+// Like MatchGUID, guid is compared with GloballyUniqueIdentifier of the templates case-insensitively.
+// Malformed templates of other GUIDs are skipped; if no template of guid is found and a template is malformed,
+// it returns FieldError of the ID of the first malformed template, which might have been of guid.
+func (c *Code) AccountInformation(guid string) (interface{}, error) {
+	s, ok := lookupScheme(guid)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSchemeNotFound, guid)
+	}
+	var malformed error
+	for _, t := range c.MerchantAccountInformation {
+		if !isMerchantAccountInformationTemplateID(t.Tag) {
+			continue
+		}
+		m, err := parseMerchantAccountInformationTemplate(t)
+		if err != nil {
+			if malformed == nil {
+				malformed = err
+			}
+			continue
+		}
+		if strings.EqualFold(m.GloballyUniqueIdentifier, guid) {
+			return parseAccountInformation(s, &m)
+		}
+	}
+	if malformed != nil {
+		return nil, malformed
+	}
+	return nil, fmt.Errorf("%w: %s", ErrAccountInformationNotFound, guid)
+}
+
+// parseAccountInformation parses m by s. Errors other than FieldError are reported as the invalid value of the template.
+func parseAccountInformation(s Scheme, m *MerchantAccountInformationTemplate) (interface{}, error) {
+	v, err := s.Parse(m)
+	if err == nil {
+		return v, nil
+	}
+	var e *FieldError
+	if errors.As(err, &e) {
+		return nil, err
+	}
+	value, _ := m.Tokenize()
+	return nil, &FieldError{
+		ID:     m.ID,
+		Field:  merchantAccountInformationTagName,
+		Value:  value,
+		Offset: -1,
+		Reason: ReasonValue,
+		msg:    fmt.Sprintf("mpm: invalid MerchantAccountInformation %s of %s: %s", m.ID, s.Name, err),
+		cause:  err,
+	}
+}
+
+// Schemes returns names of the registered schemes which the Code contains, in order of appearance.
+// Malformed templates are skipped.
+func (c *Code) Schemes() []string {
+	var names []string
+	seen := make(map[string]struct{})
+	for _, t := range c.MerchantAccountInformation {
+		if !isMerchantAccountInformationTemplateID(t.Tag) {
+			continue
+		}
+		m, err := parseMerchantAccountInformationTemplate(t)
+		if err != nil {
+			continue
+		}
+		s, ok := lookupScheme(m.GloballyUniqueIdentifier)
+		if !ok {
+			continue
+		}
+		if _, ok := seen[s.Name]; ok {
+			continue
+		}
+		seen[s.Name] = struct{}{}
+		names = append(names, s.Name)
+	}
+	return names
+}
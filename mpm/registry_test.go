@@ -0,0 +1,214 @@
+package mpm_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+type testAccount struct {
+	GUID, MerchantID string
+}
+
+func init() {
+	mpm.RegisterScheme(mpm.Scheme{
+		Name:  "TEST",
+		Match: mpm.MatchGUID("D15600000000"),
+		Parse: func(m *mpm.MerchantAccountInformationTemplate) (interface{}, error) {
+			id, ok := m.Value("05")
+			if !ok {
+				return nil, errors.New("missing merchant ID")
+			}
+			return &testAccount{GUID: m.GloballyUniqueIdentifier, MerchantID: id}, nil
+		},
+	})
+}
+
+func TestCode_AccountInformation(t *testing.T) {
+	tests := []struct {
+		name    string
+		mai     []tlv.TLV
+		guid    string
+		want    interface{}
+		wantErr error
+		wantID  string
+	}{
+		{
+			name: "ok",
+			mai: []tlv.TLV{
+				{Tag: "02", Length: "04", Value: "4111"},
+				{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+			},
+			guid: "d15600000000",
+			want: &testAccount{GUID: "D15600000000", MerchantID: "A93FO3230Q"},
+		},
+		{
+			name: "ok: GUID of the template in lower case",
+			mai: []tlv.TLV{
+				{Tag: "29", Length: "30", Value: "0012d156000000000510A93FO3230Q"},
+			},
+			guid: "D15600000000",
+			want: &testAccount{GUID: "d15600000000", MerchantID: "A93FO3230Q"},
+		},
+		{
+			name: "ok: malformed template is skipped",
+			mai: []tlv.TLV{
+				{Tag: "29", Length: "09", Value: "foobarbaz"},
+				{Tag: "30", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+			},
+			guid: "D15600000000",
+			want: &testAccount{GUID: "D15600000000", MerchantID: "A93FO3230Q"},
+		},
+		{
+			name:    "fail: unregistered scheme",
+			mai:     []tlv.TLV{{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"}},
+			guid:    "D15600000001",
+			wantErr: mpm.ErrSchemeNotFound,
+		},
+		{
+			name:    "fail: missing template",
+			mai:     []tlv.TLV{{Tag: "02", Length: "04", Value: "4111"}},
+			guid:    "D15600000000",
+			wantErr: mpm.ErrAccountInformationNotFound,
+		},
+		{
+			name: "fail: malformed template",
+			mai: []tlv.TLV{
+				{Tag: "29", Length: "28", Value: "0012D15600000001030812345678"},
+				{Tag: "30", Length: "09", Value: "foobarbaz"},
+			},
+			guid:    "D15600000000",
+			wantErr: mpm.InvalidFormat,
+			wantID:  "30",
+		},
+		{
+			name:    "fail: template without merchant ID",
+			mai:     []tlv.TLV{{Tag: "29", Length: "16", Value: "0012D15600000000"}},
+			guid:    "D15600000000",
+			wantErr: mpm.InvalidFormat,
+			wantID:  "29",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c := mpm.Code{MerchantAccountInformation: tt.mai}
+			got, err := c.AccountInformation(tt.guid)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AccountInformation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var fe *mpm.FieldError
+			if errors.As(err, &fe) && fe.ID != tt.wantID {
+				t.Errorf("AccountInformation() error ID = %s, want %s", fe.ID, tt.wantID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AccountInformation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCode_Schemes(t *testing.T) {
+	tests := []struct {
+		name string
+		mai  []tlv.TLV
+		want []string
+	}{
+		{
+			name: "once for each scheme",
+			mai: []tlv.TLV{
+				{Tag: "02", Length: "04", Value: "4111"},
+				{Tag: "29", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+				{Tag: "30", Length: "30", Value: "0012D156000000000510A93FO3230R"},
+				{Tag: "31", Length: "28", Value: "0012D15600000001030812345678"},
+			},
+			want: []string{"TEST"},
+		},
+		{
+			name: "GUID in lower case",
+			mai: []tlv.TLV{
+				{Tag: "29", Length: "30", Value: "0012d156000000000510A93FO3230Q"},
+			},
+			want: []string{"TEST"},
+		},
+		{
+			name: "malformed template is skipped",
+			mai: []tlv.TLV{
+				{Tag: "29", Length: "09", Value: "foobarbaz"},
+				{Tag: "30", Length: "30", Value: "0012D156000000000510A93FO3230Q"},
+			},
+			want: []string{"TEST"},
+		},
+		{
+			name: "no registered scheme",
+			mai: []tlv.TLV{
+				{Tag: "31", Length: "28", Value: "0012D15600000001030812345678"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c := mpm.Code{MerchantAccountInformation: tt.mai}
+			if got := c.Schemes(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Schemes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchGUID(t *testing.T) {
+	match := mpm.MatchGUID("D15600000000", "jp.or.paymentsjapan")
+	tests := []struct {
+		guid string
+		want bool
+	}{
+		{guid: "D15600000000", want: true},
+		{guid: "d15600000000", want: true},
+		{guid: "JP.OR.PAYMENTSJAPAN", want: true},
+		{guid: "D15600000001"},
+		{guid: ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.guid, func(t *testing.T) {
+			if got := match(tt.guid); got != tt.want {
+				t.Errorf("MatchGUID()(%q) = %t, want %t", tt.guid, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterScheme_Panics(t *testing.T) {
+	tests := []struct {
+		name string
+		s    mpm.Scheme
+	}{
+		{
+			name: "duplicate name",
+			s: mpm.Scheme{
+				Name:  "TEST",
+				Match: mpm.MatchGUID("D15600000000"),
+				Parse: func(*mpm.MerchantAccountInformationTemplate) (interface{}, error) { return nil, nil },
+			},
+		},
+		{
+			name: "nil Parse",
+			s:    mpm.Scheme{Name: "NIL", Match: mpm.MatchGUID("D15600000000")},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("RegisterScheme() should panic")
+				}
+			}()
+			mpm.RegisterScheme(tt.s)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package sgqr_test
+
+import (
+	"fmt"
+	"log"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/mpm/sgqr"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+func ExampleParsePayNow() {
+	id := sgqr.ID{Number: "180101B7C8D9", Version: "01.0001", PostalCode: "018956"}
+	payNow := sgqr.PayNow{ProxyType: sgqr.ProxyTypeUEN, ProxyValue: "201403121W"}
+	c := mpm.Code{
+		PayloadFormatIndicator:  "01",
+		PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+		MerchantAccountInformation: []tlv.TLV{
+			{Tag: "26", Value: payNow.String()},
+			{Tag: "51", Value: id.String()},
+		},
+		MerchantCategoryCode: "5812",
+		TransactionCurrency:  "702",
+		CountryCode:          "SG",
+		MerchantName:         "MERPAY CAFE",
+		MerchantCity:         "Singapore",
+	}
+
+	buf, err := sgqr.Encode(&c)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	dst, err := sgqr.Decode(buf)
+	if err != nil {
+		log.Fatal(err)
+	}
+	p, err := sgqr.ParsePayNow(dst)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(p.ProxyType, p.ProxyValue, p.EditableAmount)
+
+	// Output:
+	// 2 201403121W false
+}
@@ -0,0 +1,119 @@
+package sgqr
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+const (
+	idGUID     = "SG.SGQR"
+	netsIDGUID = "sg.com.nets"
+
+	idNumberLength   = 12
+	postalCodeLength = 6
+
+	tokenFormat = "%s%02d%s"
+)
+
+var versionPattern = regexp.MustCompile(`^[0-9]{2}\.[0-9]{4}$`)
+
+// ID represents a parsed SGQR ID template, which identifies the SGQR label on site.
+type ID struct {
+	GUID       string
+	Number     string // SGQR ID number
+	Version    string // e.g. "01.0001"
+	PostalCode string
+	Level      string // level of the merchant premises
+	Unit       string // unit number of the merchant premises
+	Misc       string
+}
+
+// String returns the accumulated string.
+func (i *ID) String() string {
+	guid := i.GUID
+	if guid == "" {
+		guid = idGUID
+	}
+	var b strings.Builder
+	for _, v := range []struct{ tag, value string }{
+		{"00", guid},
+		{"01", i.Number},
+		{"02", i.Version},
+		{"03", i.PostalCode},
+		{"04", i.Level},
+		{"05", i.Unit},
+		{"06", i.Misc},
+	} {
+		if v.value != "" {
+			b.WriteString(fmt.Sprintf(tokenFormat, v.tag, len(v.value), v.value))
+		}
+	}
+	return b.String()
+}
+
+func validateIDFormat(i *ID) error {
+	if len(i.Number) != idNumberLength {
+		return fmt.Errorf("len(Number) should be %d", idNumberLength)
+	}
+	if !versionPattern.MatchString(i.Version) {
+		return errors.New("version should be formatted as NN.NNNN")
+	}
+	if len(i.PostalCode) != postalCodeLength || !mpm.IsNumeric(i.PostalCode) {
+		return fmt.Errorf("postal code should be %d digits", postalCodeLength)
+	}
+	return nil
+}
+
+func init() {
+	mpm.RegisterScheme(mpm.Scheme{
+		Name:  "SGQR",
+		Match: mpm.MatchGUID(idGUID, netsIDGUID),
+		Parse: parseID,
+	})
+}
+
+func parseID(m *mpm.MerchantAccountInformationTemplate) (interface{}, error) {
+	id := ID{GUID: m.GloballyUniqueIdentifier}
+	id.Number, _ = m.Value("01")
+	id.Version, _ = m.Value("02")
+	id.PostalCode, _ = m.Value("03")
+	id.Level, _ = m.Value("04")
+	id.Unit, _ = m.Value("05")
+	id.Misc, _ = m.Value("06")
+	if err := validateIDFormat(&id); err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// ParseID validates and parses given *mpm.Code as SGQR ID.
+// If the SGQR ID is malformed, it returns *mpm.FieldError of the ID of the template.
+func ParseID(c *mpm.Code) (*ID, error) {
+	for _, guid := range []string{idGUID, netsIDGUID} {
+		v, err := c.AccountInformation(guid)
+		if errors.Is(err, mpm.ErrAccountInformationNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return v.(*ID), nil
+	}
+	return nil, errMissingID
+}
+
+var errMissingID = errors.New("missing SGQR ID")
+
+// ParseIDFromString validates and parses given string as SGQR ID.
+func ParseIDFromString(v string) (*ID, error) {
+	t, err := tlv.New("51", v)
+	if err != nil {
+		return nil, err
+	}
+	return ParseID(&mpm.Code{MerchantAccountInformation: []tlv.TLV{t}})
+}
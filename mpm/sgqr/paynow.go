@@ -0,0 +1,140 @@
+package sgqr
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+const (
+	payNowGUID = "SG.PAYNOW"
+
+	expiryDateLayout = "20060102"
+	uenMaxLength     = 10
+)
+
+// ProxyType represents the type of PayNow proxy.
+type ProxyType string
+
+const (
+	ProxyTypeMobile ProxyType = "0"
+	ProxyTypeUEN    ProxyType = "2" // Unique Entity Number of businesses
+)
+
+var mobilePattern = regexp.MustCompile(`^\+65[0-9]{8}$`)
+
+// PayNow represents a parsed PayNow template.
+type PayNow struct {
+	ProxyType      ProxyType
+	ProxyValue     string
+	EditableAmount bool   // whether the consumer may edit the amount
+	ExpiryDate     string // YYYYMMDD, optional
+}
+
+var errMissingPayNow = errors.New("missing PayNow")
+
+// String returns the accumulated string.
+func (p *PayNow) String() string {
+	editable := "0"
+	if p.EditableAmount {
+		editable = "1"
+	}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(tokenFormat, "00", len(payNowGUID), payNowGUID))
+	b.WriteString(fmt.Sprintf(tokenFormat, "01", len(p.ProxyType), p.ProxyType))
+	b.WriteString(fmt.Sprintf(tokenFormat, "02", len(p.ProxyValue), p.ProxyValue))
+	b.WriteString(fmt.Sprintf(tokenFormat, "03", len(editable), editable))
+	if p.ExpiryDate != "" {
+		b.WriteString(fmt.Sprintf(tokenFormat, "04", len(p.ExpiryDate), p.ExpiryDate))
+	}
+	return b.String()
+}
+
+// Expired reports whether the PayNow code has expired at t in Singapore time.
+func (p *PayNow) Expired(t time.Time) bool {
+	if p.ExpiryDate == "" {
+		return false
+	}
+	d, err := time.ParseInLocation(expiryDateLayout, p.ExpiryDate, singapore)
+	if err != nil {
+		return false
+	}
+	return !t.Before(d.AddDate(0, 0, 1))
+}
+
+var singapore = time.FixedZone("SGT", 8*60*60)
+
+func validatePayNowFormat(p *PayNow) error {
+	switch p.ProxyType {
+	case ProxyTypeMobile:
+		if !mobilePattern.MatchString(p.ProxyValue) {
+			return errors.New("proxy value of mobile should be +65 followed by 8 digits")
+		}
+	case ProxyTypeUEN:
+		if p.ProxyValue == "" || uenMaxLength < len(p.ProxyValue) {
+			return fmt.Errorf("len(ProxyValue) of UEN should be between 1 and %d", uenMaxLength)
+		}
+	default:
+		return fmt.Errorf("proxy type should be %s or %s", ProxyTypeMobile, ProxyTypeUEN)
+	}
+	if p.ExpiryDate != "" {
+		if _, err := time.Parse(expiryDateLayout, p.ExpiryDate); err != nil {
+			return errors.New("expiry date should be formatted as YYYYMMDD")
+		}
+	}
+	return nil
+}
+
+func init() {
+	mpm.RegisterScheme(mpm.Scheme{
+		Name:  "PayNow",
+		Match: mpm.MatchGUID(payNowGUID),
+		Parse: parsePayNow,
+	})
+}
+
+func parsePayNow(m *mpm.MerchantAccountInformationTemplate) (interface{}, error) {
+	var p PayNow
+	v, _ := m.Value("01")
+	p.ProxyType = ProxyType(v)
+	p.ProxyValue, _ = m.Value("02")
+	switch v, _ := m.Value("03"); v {
+	case "0":
+	case "1":
+		p.EditableAmount = true
+	default:
+		return nil, errors.New("editable amount indicator should be 0 or 1")
+	}
+	p.ExpiryDate, _ = m.Value("04")
+	if err := validatePayNowFormat(&p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ParsePayNow validates and parses given *mpm.Code as PayNow.
+// If the template is malformed, it returns *mpm.FieldError of the ID of the template.
+func ParsePayNow(c *mpm.Code) (*PayNow, error) {
+	v, err := c.AccountInformation(payNowGUID)
+	if errors.Is(err, mpm.ErrAccountInformationNotFound) {
+		return nil, errMissingPayNow
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v.(*PayNow), nil
+}
+
+// ParsePayNowFromString validates and parses given string as PayNow.
+func ParsePayNowFromString(v string) (*PayNow, error) {
+	t, err := tlv.New("26", v)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePayNow(&mpm.Code{MerchantAccountInformation: []tlv.TLV{t}})
+}
@@ -0,0 +1,78 @@
+package sgqr_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mercari.io/go-emv-code/mpm/sgqr"
+)
+
+func TestParsePayNow(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    *sgqr.PayNow
+		wantErr bool
+	}{
+		{
+			name:  "pass: UEN",
+			value: "0009SG.PAYNOW010120210201403121W03010040820991231",
+			want:  &sgqr.PayNow{ProxyType: sgqr.ProxyTypeUEN, ProxyValue: "201403121W", ExpiryDate: "20991231"},
+		},
+		{
+			name:  "pass: mobile",
+			value: "0009SG.PAYNOW010100211+659123456703011",
+			want:  &sgqr.PayNow{ProxyType: sgqr.ProxyTypeMobile, ProxyValue: "+6591234567", EditableAmount: true},
+		},
+		{
+			name:    "fail: mobile without country code",
+			value:   "0009SG.PAYNOW0101002089123456703010",
+			wantErr: true,
+		},
+		{
+			name:    "fail: unknown proxy type",
+			value:   "0009SG.PAYNOW010110210201403121W03010",
+			wantErr: true,
+		},
+		{
+			name:    "fail: invalid editable amount indicator",
+			value:   "0009SG.PAYNOW010120210201403121W03012",
+			wantErr: true,
+		},
+		{
+			name:    "fail: invalid expiry date",
+			value:   "0009SG.PAYNOW010120210201403121W03010040820991331",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sgqr.ParsePayNowFromString(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePayNowFromString() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePayNowFromString() = %+v, want %+v", got, tt.want)
+			}
+			if got != nil && got.String() != tt.value {
+				t.Errorf("PayNow.String() = %s, want %s", got.String(), tt.value)
+			}
+		})
+	}
+}
+
+func TestPayNow_Expired(t *testing.T) {
+	p := sgqr.PayNow{ExpiryDate: "20251231"}
+	sgt := time.FixedZone("SGT", 8*60*60)
+	if p.Expired(time.Date(2025, 12, 31, 23, 59, 0, 0, sgt)) {
+		t.Error("PayNow.Expired() on the expiry date = true, want false")
+	}
+	if !p.Expired(time.Date(2026, 1, 1, 0, 0, 0, 0, sgt)) {
+		t.Error("PayNow.Expired() after the expiry date = false, want true")
+	}
+	if (&sgqr.PayNow{}).Expired(time.Now()) {
+		t.Error("PayNow.Expired() without expiry date = true, want false")
+	}
+}
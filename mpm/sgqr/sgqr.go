@@ -0,0 +1,53 @@
+/*
+Package sgqr implements encoding and decoding of SGQR, the Singapore Quick Response Code, and PayNow.
+*/
+package sgqr
+
+import (
+	"errors"
+	"fmt"
+
+	"go.mercari.io/go-emv-code/mpm"
+)
+
+const (
+	countryCode         = "SG"
+	transactionCurrency = "702"
+)
+
+var validators = []mpm.ValidatorFunc{
+	validateID,
+	validatePayNow,
+	mpm.CountryCodeValidator(countryCode),
+	mpm.TransactionCurrencyValidator(transactionCurrency),
+}
+
+// Decode decodes payload and validates as SGQR.
+func Decode(payload []byte) (*mpm.Code, error) {
+	c, err := mpm.Decode(payload, validators...)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Encode encodes to EMV Payment Code payload.
+func Encode(c *mpm.Code) ([]byte, error) {
+	return mpm.Encode(c, validators...)
+}
+
+func validateID(c *mpm.Code) error {
+	_, err := ParseID(c)
+	if errors.Is(err, errMissingID) {
+		return mpm.NewFieldError("", "MerchantAccountInformation", "", mpm.ReasonMissing, fmt.Sprintf("sgqr: %s", err))
+	}
+	return err
+}
+
+func validatePayNow(c *mpm.Code) error {
+	_, err := ParsePayNow(c)
+	if errors.Is(err, errMissingPayNow) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,225 @@
+package sgqr_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/mpm/sgqr"
+	"go.mercari.io/go-emv-code/tlv"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	tests := []struct {
+		name       string
+		give       *mpm.Code
+		wantErr    bool
+		wantID     string
+		wantReason mpm.Reason
+	}{
+		{
+			name: "pass",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "49", Value: "0009SG.PAYNOW010120210201403121W03010040820991231"},
+					{Tag: "51", Length: "60", Value: "0007SG.SGQR0112180101B7C8D9020701.00010306018956040201050201"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "702",
+				CountryCode:          "SG",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "Singapore",
+			},
+		},
+		{
+			name: "pass: without PayNow",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "51", Length: "60", Value: "0007SG.SGQR0112180101B7C8D9020701.00010306018956040201050201"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "702",
+				CountryCode:          "SG",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "Singapore",
+			},
+		},
+		{
+			name: "err: missing SGQR ID",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "49", Value: "0009SG.PAYNOW010120210201403121W03010040820991231"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "702",
+				CountryCode:          "SG",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "Singapore",
+			},
+			wantErr:    true,
+			wantID:     "",
+			wantReason: mpm.ReasonMissing,
+		},
+		{
+			name: "err: invalid SGQR ID",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "49", Value: "0009SG.PAYNOW010120210201403121W03010040820991231"},
+					{Tag: "51", Length: "47", Value: "0007SG.SGQR0112180101B7C8D9020701.0001030518956"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "702",
+				CountryCode:          "SG",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "Singapore",
+			},
+			wantErr:    true,
+			wantID:     "51",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: invalid PayNow",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "35", Value: "0009SG.PAYNOW0101002089123456703010"},
+					{Tag: "51", Length: "60", Value: "0007SG.SGQR0112180101B7C8D9020701.00010306018956040201050201"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "702",
+				CountryCode:          "SG",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "Singapore",
+			},
+			wantErr:    true,
+			wantID:     "26",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: CountryCode is not SG",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "49", Value: "0009SG.PAYNOW010120210201403121W03010040820991231"},
+					{Tag: "51", Length: "60", Value: "0007SG.SGQR0112180101B7C8D9020701.00010306018956040201050201"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "702",
+				CountryCode:          "JP",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "Singapore",
+			},
+			wantErr:    true,
+			wantID:     "58",
+			wantReason: mpm.ReasonValue,
+		},
+		{
+			name: "err: TransactionCurrency is not SGD",
+			give: &mpm.Code{
+				PayloadFormatIndicator:  "01",
+				PointOfInitiationMethod: mpm.PointOfInitiationMethodStatic,
+				MerchantAccountInformation: []tlv.TLV{
+					{Tag: "26", Length: "49", Value: "0009SG.PAYNOW010120210201403121W03010040820991231"},
+					{Tag: "51", Length: "60", Value: "0007SG.SGQR0112180101B7C8D9020701.00010306018956040201050201"},
+				},
+				MerchantCategoryCode: "5812",
+				TransactionCurrency:  "392",
+				CountryCode:          "SG",
+				MerchantName:         "MERPAY CAFE",
+				MerchantCity:         "Singapore",
+			},
+			wantErr:    true,
+			wantID:     "53",
+			wantReason: mpm.ReasonValue,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			buf, err := sgqr.Encode(tt.give)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				var fe *mpm.FieldError
+				if !errors.As(err, &fe) || fe.ID != tt.wantID || fe.Reason != tt.wantReason {
+					t.Errorf("Encode() error = %#v, want ID %s of %s", err, tt.wantID, tt.wantReason)
+				}
+				buf, _ = (&mpm.Encoder{SkipConformance: true}).Encode(tt.give)
+				_, err := sgqr.Decode(buf)
+				if !errors.As(err, &fe) || fe.ID != tt.wantID || fe.Reason != tt.wantReason {
+					t.Errorf("Decode() error = %#v, want ID %s of %s", err, tt.wantID, tt.wantReason)
+				}
+				return
+			}
+			got, err := sgqr.Decode(buf)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.MerchantAccountInformation, tt.give.MerchantAccountInformation) {
+				t.Errorf("Decode() = %+v, want %+v", got.MerchantAccountInformation, tt.give.MerchantAccountInformation)
+			}
+		})
+	}
+}
+
+func TestParseID(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    *sgqr.ID
+		wantErr bool
+	}{
+		{
+			name:  "pass",
+			value: "0007SG.SGQR0112180101B7C8D9020701.0001030601895604020105020106021A",
+			want:  &sgqr.ID{GUID: "SG.SGQR", Number: "180101B7C8D9", Version: "01.0001", PostalCode: "018956", Level: "01", Unit: "01", Misc: "1A"},
+		},
+		{
+			name:  "pass: NETS",
+			value: "0011sg.com.nets0112180101B7C8D9020701.00010306018956",
+			want:  &sgqr.ID{GUID: "sg.com.nets", Number: "180101B7C8D9", Version: "01.0001", PostalCode: "018956"},
+		},
+		{
+			name:    "fail: invalid version",
+			value:   "0007SG.SGQR0112180101B7C8D9020401.10306018956",
+			wantErr: true,
+		},
+		{
+			name:    "fail: invalid postal code",
+			value:   "0007SG.SGQR0112180101B7C8D9020701.0001030518956",
+			wantErr: true,
+		},
+		{
+			name:    "fail: another GUID",
+			value:   "0009SG.PAYNOW010120210201403121W03010",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sgqr.ParseIDFromString(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseIDFromString() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseIDFromString() = %+v, want %+v", got, tt.want)
+			}
+			if got != nil && got.String() != tt.value {
+				t.Errorf("ID.String() = %s, want %s", got.String(), tt.value)
+			}
+		})
+	}
+}
@@ -9,14 +9,25 @@ import (
 
 // NullMerchantInformation represents Data Objects for Merchant Information—Language Template.
 type NullMerchantInformation struct {
-	LanguagePreference string `emv:"00"`
-	Name               string `emv:"01"`
-	City               string `emv:"02"`
+	LanguagePreference string    `emv:"00"`
+	Name               string    `emv:"01"`
+	City               string    `emv:"02"`
+	RFUForEMVCo        []tlv.TLV `emv:"RFUForEMVCo"` // ID 03–99
 	Valid              bool
 }
 
+const (
+	merchantInformationRFUForEMVCoIDFrom = 3
+	merchantInformationRFUForEMVCoIDTo   = 99
+)
+
 // Tokenize turns NullMerchantInformation into a string
 func (m *NullMerchantInformation) Tokenize() (string, error) {
+	return m.TokenizeUnit(tlv.LengthUnitRune)
+}
+
+// TokenizeUnit turns NullMerchantInformation into a string of Lengths in unit.
+func (m *NullMerchantInformation) TokenizeUnit(unit tlv.LengthUnit) (string, error) {
 	if m == nil {
 		return "", nil
 	}
@@ -24,15 +35,25 @@ func (m *NullMerchantInformation) Tokenize() (string, error) {
 		return "", nil
 	}
 	var buf strings.Builder
-	if err := tlv.NewEncoder(&buf, tagName, nil, nil).Encode(m); err != nil {
+	enc := tlv.NewEncoder(&buf, tagName, nil, chainTagLengthTranslators(pseudoTagTranslator(rfuForEMVCoTagName)))
+	enc.LengthUnit(unit)
+	if err := enc.Encode(m); err != nil {
 		return "", err
 	}
 	return buf.String(), nil
 }
 
 func (m *NullMerchantInformation) Scan(token []rune) error {
+	return m.ScanUnit(token, tlv.LengthUnitRune)
+}
+
+// ScanUnit parses token of Lengths in unit.
+func (m *NullMerchantInformation) ScanUnit(token []rune, unit tlv.LengthUnit) error {
 	var mm NullMerchantInformation
-	if err := tlv.NewDecoder(strings.NewReader(string(token)), tagName, MaxSize, tagLength, lenLength, nil).Decode(&mm); err != nil {
+	translatorFunc := chainTagLengthTranslators(idRangeTranslator(merchantInformationRFUForEMVCoIDFrom, merchantInformationRFUForEMVCoIDTo, rfuForEMVCoTagName))
+	dec := tlv.NewDecoder(strings.NewReader(string(token)), tagName, MaxSize, tagLength, lenLength, translatorFunc)
+	dec.LengthUnit(unit)
+	if err := dec.Decode(&mm); err != nil {
 		return err
 	}
 	mm.Valid = mm.LanguagePreference != "" && mm.Name != ""
@@ -40,7 +61,77 @@ func (m *NullMerchantInformation) Scan(token []rune) error {
 	return nil
 }
 
+// AdditionalDataFieldTemplate represents Data Objects for Additional Data Field Template.
+type AdditionalDataFieldTemplate struct {
+	BillNumber                     string    `emv:"01"`
+	MobileNumber                   string    `emv:"02"`
+	StoreLabel                     string    `emv:"03"`
+	LoyaltyNumber                  string    `emv:"04"`
+	ReferenceLabel                 string    `emv:"05"`
+	CustomerLabel                  string    `emv:"06"`
+	TerminalLabel                  string    `emv:"07"`
+	PurposeOfTransaction           string    `emv:"08"`
+	AdditionalConsumerDataRequest  string    `emv:"09"`
+	RFUForEMVCo                    []tlv.TLV `emv:"RFUForEMVCo"`
+	PaymentSystemSpecificTemplates []tlv.TLV `emv:"PaymentSystemSpecificTemplates"`
+}
+
+const (
+	additionalDataRFUForEMVCoIDFrom  = 10
+	additionalDataRFUForEMVCoIDTo    = 49
+	additionalDataRFUForEMVCoTagName = "RFUForEMVCo"
+
+	paymentSystemSpecificTemplatesIDFrom  = 50
+	paymentSystemSpecificTemplatesIDTo    = 99
+	paymentSystemSpecificTemplatesTagName = "PaymentSystemSpecificTemplates"
+)
+
+// Tokenize turns AdditionalDataFieldTemplate into a string
+func (a *AdditionalDataFieldTemplate) Tokenize() (string, error) {
+	return a.TokenizeUnit(tlv.LengthUnitRune)
+}
+
+// TokenizeUnit turns AdditionalDataFieldTemplate into a string of Lengths in unit.
+func (a *AdditionalDataFieldTemplate) TokenizeUnit(unit tlv.LengthUnit) (string, error) {
+	if a == nil {
+		return "", nil
+	}
+	translatorFunc := chainTagLengthTranslators(
+		pseudoTagTranslator(additionalDataRFUForEMVCoTagName),
+		pseudoTagTranslator(paymentSystemSpecificTemplatesTagName),
+	)
+	var buf strings.Builder
+	enc := tlv.NewEncoder(&buf, tagName, nil, translatorFunc)
+	enc.LengthUnit(unit)
+	if err := enc.Encode(a); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (a *AdditionalDataFieldTemplate) Scan(token []rune) error {
+	return a.ScanUnit(token, tlv.LengthUnitRune)
+}
+
+// ScanUnit parses token of Lengths in unit.
+func (a *AdditionalDataFieldTemplate) ScanUnit(token []rune, unit tlv.LengthUnit) error {
+	var aa AdditionalDataFieldTemplate
+	translatorFunc := chainTagLengthTranslators(
+		idRangeTranslator(additionalDataRFUForEMVCoIDFrom, additionalDataRFUForEMVCoIDTo, additionalDataRFUForEMVCoTagName),
+		idRangeTranslator(paymentSystemSpecificTemplatesIDFrom, paymentSystemSpecificTemplatesIDTo, paymentSystemSpecificTemplatesTagName),
+	)
+	dec := tlv.NewDecoder(strings.NewReader(string(token)), tagName, MaxSize, tagLength, lenLength, translatorFunc)
+	dec.LengthUnit(unit)
+	if err := dec.Decode(&aa); err != nil {
+		return err
+	}
+	*a = aa
+	return nil
+}
+
 // PointOfInitiationMethod represents Data Objects for Point of Initiation Method.
+// Point of Initiation Method is optional in EMV QRCPS, so ConformanceValidators accept the zero value
+// and ID 01 is omitted. Pass StaticDynamicValidators to report it as missing.
 type PointOfInitiationMethod string
 
 const (
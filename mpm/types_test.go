@@ -1,9 +1,11 @@
 package mpm_test
 
 import (
+	"reflect"
 	"testing"
 
 	"go.mercari.io/go-emv-code/mpm"
+	"go.mercari.io/go-emv-code/tlv"
 )
 
 func TestTipOrConvenienceIndicator_Tokenize(t *testing.T) {
@@ -379,3 +381,120 @@ func TestNullMerchantInformation_Scan(t *testing.T) {
 		})
 	}
 }
+
+func TestAdditionalDataFieldTemplate_Tokenize(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    *mpm.AdditionalDataFieldTemplate
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "give nil",
+			give:    nil,
+			want:    "",
+			wantErr: false,
+		},
+		{
+			name:    "give empty mpm.AdditionalDataFieldTemplate",
+			give:    &mpm.AdditionalDataFieldTemplate{},
+			want:    "",
+			wantErr: false,
+		},
+		{
+			name: "give valid mpm.AdditionalDataFieldTemplate",
+			give: &mpm.AdditionalDataFieldTemplate{
+				BillNumber:                    "INV-001",
+				StoreLabel:                    "1234",
+				CustomerLabel:                 "***",
+				TerminalLabel:                 "A6008667",
+				AdditionalConsumerDataRequest: "ME",
+				RFUForEMVCo: []tlv.TLV{
+					{Tag: "10", Length: "03", Value: "abc"},
+				},
+				PaymentSystemSpecificTemplates: []tlv.TLV{
+					{Tag: "50", Length: "12", Value: "0008com.test"},
+				},
+			},
+			want:    "0107INV-001030412340603***0708A60086670902ME1003abc50120008com.test",
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dst, err := tt.give.Tokenize()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AdditionalDataFieldTemplate.Tokenize error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.want != dst {
+				t.Errorf("AdditionalDataFieldTemplate.Tokenize = %v, want %v", dst, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdditionalDataFieldTemplate_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    []rune
+		want    mpm.AdditionalDataFieldTemplate
+		wantErr bool
+	}{
+		{
+			name:    "give nil",
+			give:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "give wrong string",
+			give:    []rune("wrong_value"),
+			wantErr: true,
+		},
+		{
+			name:    "give negative length",
+			give:    []rune("01-1xxxx"),
+			wantErr: true,
+		},
+		{
+			name:    "give signed length",
+			give:    []rune("01+1x"),
+			wantErr: true,
+		},
+		{
+			name: "give string",
+			give: []rune("0107INV-001030412340603***0708A60086670902ME1003abc50120008com.test"),
+			want: mpm.AdditionalDataFieldTemplate{
+				BillNumber:                    "INV-001",
+				StoreLabel:                    "1234",
+				CustomerLabel:                 "***",
+				TerminalLabel:                 "A6008667",
+				AdditionalConsumerDataRequest: "ME",
+				RFUForEMVCo: []tlv.TLV{
+					{Tag: "10", Length: "03", Value: "abc"},
+				},
+				PaymentSystemSpecificTemplates: []tlv.TLV{
+					{Tag: "50", Length: "12", Value: "0008com.test"},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var dst mpm.AdditionalDataFieldTemplate
+			err := dst.Scan(tt.give)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AdditionalDataFieldTemplate.Scan error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(dst, tt.want) {
+				t.Errorf("AdditionalDataFieldTemplate.Scan = %+v, want %+v", dst, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Package refdata provides the reference tables of ISO 4217 currencies, ISO 3166-1 countries
+// and ISO 18245 merchant category codes used in EMV Payment Codes.
+package refdata // import "go.mercari.io/go-emv-code/refdata"
+
+import (
+	"embed"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version is the date of the snapshot of the tables.
+const Version = "2025-01-01"
+
+//go:embed data/*.csv
+var data embed.FS
+
+// Currency represents an ISO 4217 currency.
+type Currency struct {
+	// Numeric is the three-digit numeric code, e.g. "392".
+	Numeric string
+	// Alpha is the three-letter alphabetic code, e.g. "JPY".
+	Alpha string
+	// Exponent is the number of digits after the decimal mark of the minor unit, e.g. 0 for JPY.
+	Exponent int
+}
+
+// merchantCategory represents the description of the merchant category codes between From and To.
+type merchantCategory struct {
+	From, To    string
+	Description string
+}
+
+var (
+	currenciesByNumeric = make(map[string]Currency)
+	currenciesByAlpha   = make(map[string]Currency)
+	countries           = make(map[string]struct{})
+	merchantCategories  []merchantCategory
+)
+
+func init() {
+	for _, r := range records("data/currency.csv") {
+		exponent, err := strconv.Atoi(r[2])
+		if err != nil {
+			panic(fmt.Sprintf("refdata: invalid exponent of currency %s: %s", r[0], err))
+		}
+		c := Currency{Numeric: r[0], Alpha: r[1], Exponent: exponent}
+		currenciesByNumeric[c.Numeric] = c
+		currenciesByAlpha[c.Alpha] = c
+	}
+	for _, r := range records("data/country.csv") {
+		countries[r[0]] = struct{}{}
+	}
+	for _, r := range records("data/mcc.csv") {
+		from, to := r[0], r[0]
+		if i := strings.IndexByte(r[0], '-'); i >= 0 {
+			from, to = r[0][:i], r[0][i+1:]
+		}
+		merchantCategories = append(merchantCategories, merchantCategory{From: from, To: to, Description: r[1]})
+	}
+	sort.Slice(merchantCategories, func(i, j int) bool {
+		return merchantCategories[i].From < merchantCategories[j].From
+	})
+}
+
+// records returns the records of the embedded CSV file without its header.
+func records(name string) [][]string {
+	f, err := data.Open(name)
+	if err != nil {
+		panic(fmt.Sprintf("refdata: %s", err))
+	}
+	defer f.Close()
+	s, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("refdata: %s: %s", name, err))
+	}
+	return s[1:]
+}
+
+// CurrencyByNumeric returns the currency of ISO 4217 numeric code, e.g. "392".
+func CurrencyByNumeric(code string) (Currency, bool) {
+	c, ok := currenciesByNumeric[code]
+	return c, ok
+}
+
+// CurrencyByAlpha returns the currency of ISO 4217 alphabetic code, e.g. "JPY".
+// Codes are compared case-insensitively.
+func CurrencyByAlpha(code string) (Currency, bool) {
+	c, ok := currenciesByAlpha[strings.ToUpper(code)]
+	return c, ok
+}
+
+// IsCountryCode reports whether code is an ISO 3166-1 alpha-2 country code, e.g. "JP".
+func IsCountryCode(code string) bool {
+	_, ok := countries[code]
+	return ok
+}
+
+// MerchantCategory returns the description of ISO 18245 merchant category code, e.g. "5812".
+func MerchantCategory(code string) (string, bool) {
+	if len(code) != 4 {
+		return "", false
+	}
+	i := sort.Search(len(merchantCategories), func(i int) bool {
+		return code < merchantCategories[i].From
+	})
+	if i == 0 {
+		return "", false
+	}
+	if m := merchantCategories[i-1]; code <= m.To {
+		return m.Description, true
+	}
+	return "", false
+}
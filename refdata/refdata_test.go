@@ -0,0 +1,67 @@
+package refdata
+
+import "testing"
+
+func TestCurrencyByNumeric(t *testing.T) {
+	tests := []struct {
+		code   string
+		want   Currency
+		wantOK bool
+	}{
+		{code: "392", want: Currency{Numeric: "392", Alpha: "JPY", Exponent: 0}, wantOK: true},
+		{code: "840", want: Currency{Numeric: "840", Alpha: "USD", Exponent: 2}, wantOK: true},
+		{code: "414", want: Currency{Numeric: "414", Alpha: "KWD", Exponent: 3}, wantOK: true},
+		{code: "999"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.code, func(t *testing.T) {
+			got, ok := CurrencyByNumeric(tt.code)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("CurrencyByNumeric() = %+v, %v, want %+v, %v", got, ok, tt.want, tt.wantOK)
+			}
+			if !tt.wantOK {
+				return
+			}
+			if got, ok := CurrencyByAlpha(tt.want.Alpha); got != tt.want || !ok {
+				t.Errorf("CurrencyByAlpha() = %+v, %v, want %+v, true", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCountryCode(t *testing.T) {
+	for code, want := range map[string]bool{"JP": true, "CN": true, "jp": false, "XX": false, "": false} {
+		if got := IsCountryCode(code); got != want {
+			t.Errorf("IsCountryCode(%q) = %v, want %v", code, got, want)
+		}
+	}
+	if len(countries) != 249 {
+		t.Errorf("len(countries) = %d, want 249", len(countries))
+	}
+}
+
+func TestMerchantCategory(t *testing.T) {
+	tests := []struct {
+		code   string
+		want   string
+		wantOK bool
+	}{
+		{code: "5812", want: "Eating Places and Restaurants", wantOK: true},
+		{code: "3000", want: "Airlines", wantOK: true},
+		{code: "3750", want: "Lodging - Hotels, Motels and Resorts", wantOK: true},
+		{code: "0742", want: "Veterinary Services", wantOK: true},
+		{code: "0001"},
+		{code: "4000"},
+		{code: "58120"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.code, func(t *testing.T) {
+			got, ok := MerchantCategory(tt.code)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("MerchantCategory() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package tlv
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	berConstructedBit    = 0x20
+	berTagNumberMask     = 0x1f
+	berTagMoreBit        = 0x80
+	berLengthLongFormBit = 0x80
+	berMaxLengthOctets   = 4
+	berPaddingZero       = 0x00
+	berPaddingOne        = 0xff
+)
+
+// BERTLV represents a BER-TLV data object.
+// Tag is the hexadecimal representation of the tag bytes, e.g. "9F02".
+type BERTLV struct {
+	Tag   string
+	Value []byte
+}
+
+// Constructed returns true if the tag of t represents a constructed data object.
+func (t *BERTLV) Constructed() bool {
+	b, err := hex.DecodeString(t.Tag)
+	if err != nil || len(b) == 0 {
+		return false
+	}
+	return b[0]&berConstructedBit != 0
+}
+
+// Bytes returns BER-TLV encoded t.
+func (t *BERTLV) Bytes() ([]byte, error) {
+	return AppendBERTLV(nil, *t)
+}
+
+// AppendBERTLV appends BER-TLV encoded tlvs to dst and returns the extended buffer.
+func AppendBERTLV(dst []byte, tlvs ...BERTLV) ([]byte, error) {
+	for _, t := range tlvs {
+		tag, err := hex.DecodeString(t.Tag)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag %s: %s", t.Tag, err)
+		}
+		if _, n, err := readBERTag(tag); err != nil || n != len(tag) {
+			return nil, fmt.Errorf("invalid tag %s", t.Tag)
+		}
+		dst = append(dst, tag...)
+		dst = appendBERLength(dst, len(t.Value))
+		dst = append(dst, t.Value...)
+	}
+	return dst, nil
+}
+
+// ParseBERTLV parses every BER-TLV data object in b at a single level.
+// Values of constructed data objects are kept as is. Padding bytes 0x00 and 0xFF before, between and after
+// data objects are skipped as EMV Book 3 Annex B and ISO/IEC 7816-4 allow, so they are not written back by AppendBERTLV.
+func ParseBERTLV(b []byte) ([]BERTLV, error) {
+	var s []BERTLV
+	for b = b[skipBERPadding(b):]; len(b) > 0; b = b[skipBERPadding(b):] {
+		t, n, err := ReadBERTLV(b)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, t)
+		b = b[n:]
+	}
+	return s, nil
+}
+
+// ReadBERTLV reads the first BER-TLV data object from b and returns it with the number of bytes consumed.
+// Unlike ParseBERTLV, b should not start with padding.
+func ReadBERTLV(b []byte) (BERTLV, int, error) {
+	tag, n, err := readBERTag(b)
+	if err != nil {
+		return BERTLV{}, 0, err
+	}
+	length, nn, err := readBERLength(b[n:])
+	if err != nil {
+		return BERTLV{}, 0, err
+	}
+	n += nn
+	return BERTLV{
+		Tag:   strings.ToUpper(hex.EncodeToString(tag)),
+		Value: b[n : n+length],
+	}, n + length, nil
+}
+
+// skipBERPadding returns the number of padding bytes at the beginning of b.
+func skipBERPadding(b []byte) int {
+	n := 0
+	for n < len(b) && (b[n] == berPaddingZero || b[n] == berPaddingOne) {
+		n++
+	}
+	return n
+}
+
+func readBERTag(b []byte) ([]byte, int, error) {
+	if len(b) < 1 {
+		return nil, 0, &MalformedPayloadError{msg: "cannot read tag"}
+	}
+	n := 1
+	if b[0]&berTagNumberMask == berTagNumberMask {
+		for {
+			if len(b) < n+1 {
+				return nil, 0, &MalformedPayloadError{msg: "cannot read subsequent tag bytes"}
+			}
+			n++
+			if b[n-1]&berTagMoreBit == 0 {
+				break
+			}
+		}
+	}
+	return b[:n], n, nil
+}
+
+// readBERLength reads the length at the beginning of b, which should also contain the value.
+func readBERLength(b []byte) (int, int, error) {
+	if len(b) < 1 {
+		return 0, 0, &MalformedPayloadError{msg: "cannot read value length"}
+	}
+	if b[0]&berLengthLongFormBit == 0 {
+		return checkBERLength(uint64(b[0]), 1, b)
+	}
+	octets := int(b[0] &^ berLengthLongFormBit)
+	if octets == 0 || berMaxLengthOctets < octets {
+		return 0, 0, &MalformedPayloadError{msg: fmt.Sprintf("unsupported number of length octets %d", octets)}
+	}
+	if len(b) < 1+octets {
+		return 0, 0, &MalformedPayloadError{msg: "cannot read value length"}
+	}
+	var length uint64
+	for _, v := range b[1 : 1+octets] {
+		length = length<<8 | uint64(v)
+	}
+	return checkBERLength(length, 1+octets, b)
+}
+
+// checkBERLength returns length if the value of length fits in b after the n bytes of the length.
+func checkBERLength(length uint64, n int, b []byte) (int, int, error) {
+	if uint64(len(b)-n) < length {
+		return 0, 0, &MalformedPayloadError{msg: fmt.Sprintf("cannot read value of length %d from %d bytes", length, len(b)-n)}
+	}
+	return int(length), n, nil
+}
+
+func appendBERLength(dst []byte, length int) []byte {
+	if length < berLengthLongFormBit {
+		return append(dst, byte(length))
+	}
+	var octets []byte
+	for l := length; l > 0; l >>= 8 {
+		octets = append([]byte{byte(l)}, octets...)
+	}
+	dst = append(dst, berLengthLongFormBit|byte(len(octets)))
+	return append(dst, octets...)
+}
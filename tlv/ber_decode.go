@@ -0,0 +1,189 @@
+package tlv
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	// BEROthersTag is the tag name of a []BERTLV field which collects every data object without corresponding field.
+	BEROthersTag = "*"
+	// BERRawTag is the tag name of a []byte field which retains the encoding of the data objects of the struct as decoded.
+	// BEREncoder writes it as is while the struct is unchanged, so that the order of the data objects,
+	// the form of their lengths and data objects of zero length survive a round trip.
+	BERRawTag = "raw"
+)
+
+// BERDecoder decodes BER-TLV payload into a struct.
+//
+// Fields are bound to data objects by hexadecimal tags, e.g. `emv:"9F02"`.
+// Supported field types are string, []byte, []BERTLV (the data objects nested in a constructed data object),
+// struct (a constructed data object), slices of them for repeated data objects and BERScanner.
+// A data object bound to a field other than a slice of repeated data objects should appear at most once,
+// otherwise Decode returns DuplicateTagError.
+// Padding bytes 0x00 and 0xFF around data objects are skipped as ParseBERTLV does.
+type BERDecoder struct {
+	b       []byte
+	tagName string
+}
+
+// NewBERDecoder returns a new decoder that reads from b.
+func NewBERDecoder(b []byte, tagName string) *BERDecoder {
+	return &BERDecoder{
+		b:       b,
+		tagName: tagName,
+	}
+}
+
+// Decode decodes BER-TLV payload and stores it in the value pointed to by dst.
+func (d *BERDecoder) Decode(dst interface{}) error {
+	v := reflect.ValueOf(dst)
+
+	if v.Kind() != reflect.Ptr {
+		return errors.New("dst should be a pointer, not a value")
+	}
+	if deref(v.Type()).Kind() != reflect.Struct {
+		return errors.New("dst should be a struct")
+	}
+	if v.IsNil() {
+		return errors.New("nil pointer passed")
+	}
+
+	return decodeBER(v.Elem(), d.b, 0, d.tagName)
+}
+
+// decodeBER decodes b at offset off of the payload into v.
+func decodeBER(v reflect.Value, b []byte, off int, tagName string) error {
+	fields := berFields(v.Type(), tagName)
+	m := make(map[string][]int, len(fields))
+	for _, f := range fields {
+		m[f.id] = f.index
+	}
+
+	if index, ok := m[BERRawTag]; ok {
+		if f := v.FieldByIndex(index); f.Type() == reflect.TypeOf([]byte(nil)) {
+			f.SetBytes(append([]byte(nil), b...))
+		} else {
+			return fmt.Errorf("field of tag %s should be []byte", BERRawTag)
+		}
+	}
+
+	seen := make(map[string]int)
+	for n := skipBERPadding(b); n < len(b); n += skipBERPadding(b[n:]) {
+		t, nn, err := ReadBERTLV(b[n:])
+		if err != nil {
+			return err
+		}
+		start := off + n
+		n += nn
+
+		index, ok := m[t.Tag]
+		if !ok {
+			if index, ok = m[BEROthersTag]; ok {
+				f := v.FieldByIndex(index)
+				f.Set(reflect.Append(f, reflect.ValueOf(t)))
+			}
+			continue
+		}
+		f := v.FieldByIndex(index)
+		if !isBERRepeatable(f.Type()) {
+			if first, ok := seen[t.Tag]; ok {
+				return &DuplicateTagError{Tag: t.Tag, Offset: first, DuplicateOffset: start}
+			}
+			seen[t.Tag] = start
+		}
+		if err := scanBER(f, t, off+n-len(t.Value), tagName); err != nil {
+			return fmt.Errorf("tag %s: %w", t.Tag, err)
+		}
+	}
+
+	return nil
+}
+
+// scanBER stores t, whose value is at offset off of the payload, in f.
+func scanBER(f reflect.Value, t BERTLV, off int, tagName string) error {
+	if isBERScannable(f.Type()) {
+		return f.Addr().Interface().(BERScanner).Scan(t.Value)
+	}
+
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(string(t.Value))
+		return nil
+	case reflect.Struct:
+		return decodeBER(f, t.Value, off, tagName)
+	case reflect.Slice:
+		typ := f.Type().Elem()
+
+		switch {
+		case typ.Kind() == reflect.Uint8:
+			f.SetBytes(append([]byte(nil), t.Value...))
+			return nil
+		case typ == reflect.TypeOf(BERTLV{}):
+			s, err := ParseBERTLV(t.Value)
+			if err != nil {
+				return err
+			}
+			f.Set(reflect.ValueOf(s))
+			return nil
+		case typ.Kind() == reflect.Struct || isBERScannable(typ):
+			rv := reflect.New(typ).Elem()
+			if err := scanBER(rv, t, off, tagName); err != nil {
+				return err
+			}
+			f.Set(reflect.Append(f, rv))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unsupported field type %s passed", f.Type())
+}
+
+type berField struct {
+	id    string
+	index []int
+}
+
+// berFields returns tagged fields of t in order. Fields of embedded structs without tag are promoted.
+func berFields(t reflect.Type, tagName string) []berField {
+	s := make([]berField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		id, ok := f.Tag.Lookup(tagName)
+		if !ok {
+			if f.Anonymous && f.Type.Kind() == reflect.Struct {
+				for _, ff := range berFields(f.Type, tagName) {
+					s = append(s, berField{ff.id, append([]int{i}, ff.index...)})
+				}
+			}
+			continue
+		}
+		if id != BERRawTag {
+			id = strings.ToUpper(id)
+		}
+		s = append(s, berField{id, []int{i}})
+	}
+	return s
+}
+
+// isBERRepeatable reports whether a field of t collects every data object of its tag.
+func isBERRepeatable(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice || isBERScannable(t) {
+		return false
+	}
+	elem := t.Elem()
+	return elem.Kind() == reflect.Struct && elem != reflect.TypeOf(BERTLV{}) || isBERScannable(elem)
+}
+
+// BERScanner is interface for parse various types from BER-TLV value.
+type BERScanner interface {
+	Scan([]byte) (err error)
+}
+
+var _berScannerInterface = reflect.TypeOf((*BERScanner)(nil)).Elem()
+
+func isBERScannable(t reflect.Type) bool {
+	return reflect.PtrTo(t).Implements(_berScannerInterface)
+}
@@ -0,0 +1,271 @@
+package tlv
+
+import (
+	"bytes"
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type berTransparentTemplate struct {
+	ApplicationCryptogram []byte   `emv:"9F26"`
+	ATC                   []byte   `emv:"9F36"`
+	Others                []BERTLV `emv:"*"`
+}
+
+type berCommonDataObjects struct {
+	ADFName          []byte `emv:"4f"`
+	ApplicationLabel string `emv:"50"`
+}
+
+type berApplicationTemplate struct {
+	berCommonDataObjects
+	Transparent berTransparentTemplate `emv:"63"`
+}
+
+type berAmount struct {
+	Value string
+}
+
+func (a *berAmount) Scan(b []byte) error {
+	a.Value = hex.EncodeToString(b)
+	return nil
+}
+
+func (a *berAmount) Tokenize() ([]byte, error) {
+	return hex.DecodeString(a.Value)
+}
+
+type berPayload struct {
+	PayloadFormatIndicator string                   `emv:"85"`
+	ApplicationTemplates   []berApplicationTemplate `emv:"61"`
+	CommonDataTemplate     []BERTLV                 `emv:"62"`
+	AmountAuthorised       berAmount                `emv:"9F02"`
+}
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestBERDecoder_Decode(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    []byte
+		want    berPayload
+		wantErr bool
+	}{
+		{
+			name: "pass",
+			give: mustDecodeHex("8505435056303161134F07A0000000555555500850726F647563743161194F07A0000000666666630E9F26021122" +
+				"9F360200059F2701806203820100" + "9F0206000000000100"),
+			want: berPayload{
+				PayloadFormatIndicator: "CPV01",
+				ApplicationTemplates: []berApplicationTemplate{
+					{
+						berCommonDataObjects: berCommonDataObjects{
+							ADFName:          mustDecodeHex("A0000000555555"),
+							ApplicationLabel: "Product1",
+						},
+					},
+					{
+						berCommonDataObjects: berCommonDataObjects{
+							ADFName: mustDecodeHex("A0000000666666"),
+						},
+						Transparent: berTransparentTemplate{
+							ApplicationCryptogram: mustDecodeHex("1122"),
+							ATC:                   mustDecodeHex("0005"),
+							Others: []BERTLV{
+								{Tag: "9F27", Value: mustDecodeHex("80")},
+							},
+						},
+					},
+				},
+				CommonDataTemplate: []BERTLV{
+					{Tag: "82", Value: mustDecodeHex("00")},
+				},
+				AmountAuthorised: berAmount{Value: "000000000100"},
+			},
+		},
+		{
+			name:    "err: malformed nested template",
+			give:    mustDecodeHex("6103630299"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got berPayload
+			err := NewBERDecoder(tt.give, "emv").Decode(&got)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BERDecoder.Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BERDecoder.Decode() = %+v, want %+v", got, tt.want)
+			}
+
+			var buf bytes.Buffer
+			if err := NewBEREncoder(&buf, "emv").Encode(&got); err != nil {
+				t.Fatalf("BEREncoder.Encode() error = %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), tt.give) {
+				t.Errorf("BEREncoder.Encode() = %X, want %X", buf.Bytes(), tt.give)
+			}
+		})
+	}
+}
+
+func TestBERDecoder_Decode_invalidDst(t *testing.T) {
+	var v berPayload
+	var s string
+	var p *berPayload
+	for _, dst := range []interface{}{v, &s, p} {
+		if err := NewBERDecoder(nil, "emv").Decode(dst); err == nil {
+			t.Errorf("BERDecoder.Decode(%T) should return error", dst)
+		}
+	}
+}
+
+type berRawTemplate struct {
+	Raw              []byte   `emv:"raw"`
+	ApplicationLabel string   `emv:"50"`
+	AmountAuthorised []byte   `emv:"9F02"`
+	Others           []BERTLV `emv:"*"`
+}
+
+type berRawPayload struct {
+	Raw                    []byte         `emv:"raw"`
+	PayloadFormatIndicator string         `emv:"85"`
+	ApplicationTemplate    berRawTemplate `emv:"61"`
+}
+
+func TestBERDecoder_Decode_roundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    string
+		want    berRawTemplate
+		wantErr bool
+	}{
+		{
+			name: "pass: data objects out of field order",
+			give: "9F02020001500141",
+			want: berRawTemplate{ApplicationLabel: "A", AmountAuthorised: mustDecodeHex("0001")},
+		},
+		{
+			name: "pass: long form length of a short value",
+			give: "5081024142",
+			want: berRawTemplate{ApplicationLabel: "AB"},
+		},
+		{
+			name: "pass: data object of zero length",
+			give: "50009F02020001",
+			want: berRawTemplate{AmountAuthorised: mustDecodeHex("0001")},
+		},
+		{
+			name: "pass: unknown data objects between fields",
+			give: "5001419F2701809F02020001",
+			want: berRawTemplate{
+				ApplicationLabel: "A",
+				AmountAuthorised: mustDecodeHex("0001"),
+				Others:           []BERTLV{{Tag: "9F27", Value: mustDecodeHex("80")}},
+			},
+		},
+		{
+			name: "pass: padding around data objects",
+			give: "00500141FF009F0202000100",
+			want: berRawTemplate{ApplicationLabel: "A", AmountAuthorised: mustDecodeHex("0001")},
+		},
+		{
+			name:    "err: duplicated data object",
+			give:    "500141500142",
+			wantErr: true,
+		},
+		{
+			name:    "err: duplicated data object of zero length",
+			give:    "5000500141",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			give := mustDecodeHex(tt.give)
+			var got berRawTemplate
+			err := NewBERDecoder(give, "emv").Decode(&got)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BERDecoder.Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if _, ok := err.(*DuplicateTagError); !ok {
+					t.Errorf("BERDecoder.Decode() error = %#v, want *DuplicateTagError", err)
+				}
+				return
+			}
+			tt.want.Raw = give
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BERDecoder.Decode() = %+v, want %+v", got, tt.want)
+			}
+
+			var buf bytes.Buffer
+			if err := NewBEREncoder(&buf, "emv").Encode(&got); err != nil {
+				t.Fatalf("BEREncoder.Encode() error = %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), give) {
+				t.Errorf("BEREncoder.Encode() = %X, want %X", buf.Bytes(), give)
+			}
+		})
+	}
+}
+
+func TestBEREncoder_Encode_raw(t *testing.T) {
+	give := mustDecodeHex("61089F0202000150014185054350563031")
+	tests := []struct {
+		name   string
+		modify func(*berRawPayload)
+		want   string
+	}{
+		{
+			name:   "unchanged",
+			modify: func(*berRawPayload) {},
+			want:   "61089F0202000150014185054350563031",
+		},
+		{
+			name:   "changed root keeps unchanged template as is",
+			modify: func(p *berRawPayload) { p.PayloadFormatIndicator = "CPV02" },
+			want:   "85054350563032" + "61089F02020001500141",
+		},
+		{
+			name:   "changed template is written in field order",
+			modify: func(p *berRawPayload) { p.ApplicationTemplate.ApplicationLabel = "B" },
+			want:   "85054350563031" + "61085001429F02020001",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var p berRawPayload
+			if err := NewBERDecoder(give, "emv").Decode(&p); err != nil {
+				t.Fatalf("BERDecoder.Decode() error = %v", err)
+			}
+			tt.modify(&p)
+
+			var buf bytes.Buffer
+			if err := NewBEREncoder(&buf, "emv").Encode(&p); err != nil {
+				t.Fatalf("BEREncoder.Encode() error = %v", err)
+			}
+			if got := hex.EncodeToString(buf.Bytes()); got != strings.ToLower(tt.want) {
+				t.Errorf("BEREncoder.Encode() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package tlv
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// BEREncoder writes BER-TLV payload to an output stream.
+// Data objects are written in the order of the fields, the ones of BEROthersTag field as is.
+// A struct of BERRawTag field is written as its raw encoding instead if it decodes into the same value.
+type BEREncoder struct {
+	w       io.Writer
+	tagName string
+}
+
+// NewBEREncoder returns a new encoder that writes to w.
+func NewBEREncoder(w io.Writer, tagName string) *BEREncoder {
+	return &BEREncoder{
+		w:       w,
+		tagName: tagName,
+	}
+}
+
+// Encode writes BER-TLV payload of src to the stream.
+func (e *BEREncoder) Encode(src interface{}) error {
+	v := reflect.ValueOf(src)
+
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return errors.New("nil pointer passed")
+	}
+	if v.Elem().Kind() != reflect.Struct {
+		return errors.New("src should be a struct")
+	}
+
+	b, err := encodeBER(v.Elem(), e.tagName)
+	if err != nil {
+		return err
+	}
+	if _, err := e.w.Write(b); err != nil {
+		return fmt.Errorf("failed to write body: %s", err)
+	}
+	return nil
+}
+
+func encodeBER(v reflect.Value, tagName string) ([]byte, error) {
+	fields := berFields(v.Type(), tagName)
+	if raw, ok := rawBER(v, fields, tagName); ok {
+		return raw, nil
+	}
+
+	var b []byte
+	for _, field := range fields {
+		f := v.FieldByIndex(field.index)
+
+		if field.id == BERRawTag {
+			continue
+		}
+		if field.id == BEROthersTag {
+			s, ok := f.Interface().([]BERTLV)
+			if !ok {
+				return nil, fmt.Errorf("field of tag %s should be []BERTLV", BEROthersTag)
+			}
+			var err error
+			if b, err = AppendBERTLV(b, s...); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		values, err := tokenizeBER(f, tagName)
+		if err != nil {
+			return nil, fmt.Errorf("tag %s: %s", field.id, err)
+		}
+		for _, value := range values {
+			if len(value) < 1 {
+				continue // value should be non-zero length
+			}
+			if b, err = AppendBERTLV(b, BERTLV{Tag: field.id, Value: value}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return b, nil
+}
+
+// rawBER returns the value of BERRawTag field of v if v is unchanged since it was decoded from the value.
+func rawBER(v reflect.Value, fields []berField, tagName string) ([]byte, bool) {
+	for _, field := range fields {
+		if field.id != BERRawTag {
+			continue
+		}
+		raw, ok := v.FieldByIndex(field.index).Interface().([]byte)
+		if !ok || raw == nil {
+			return nil, false
+		}
+		decoded := reflect.New(v.Type()).Elem()
+		if err := decodeBER(decoded, raw, 0, tagName); err != nil {
+			return nil, false
+		}
+		if !reflect.DeepEqual(decoded.Interface(), v.Interface()) {
+			return nil, false
+		}
+		return append([]byte(nil), raw...), true
+	}
+	return nil, false
+}
+
+// tokenizeBER returns values of f. Slices of constructed data objects result in a value per element.
+func tokenizeBER(f reflect.Value, tagName string) ([][]byte, error) {
+	if isBERTokenizable(f.Type()) {
+		if !f.CanAddr() {
+			fv := reflect.New(f.Type())
+			fv.Elem().Set(f)
+			f = fv.Elem()
+		}
+		b, err := f.Addr().Interface().(BERTokenizer).Tokenize()
+		if err != nil {
+			return nil, err
+		}
+		return [][]byte{b}, nil
+	}
+
+	switch f.Kind() {
+	case reflect.String:
+		return [][]byte{[]byte(f.String())}, nil
+	case reflect.Struct:
+		b, err := encodeBER(f, tagName)
+		if err != nil {
+			return nil, err
+		}
+		return [][]byte{b}, nil
+	case reflect.Slice:
+		typ := f.Type().Elem()
+
+		switch {
+		case typ.Kind() == reflect.Uint8:
+			return [][]byte{f.Bytes()}, nil
+		case typ == reflect.TypeOf(BERTLV{}):
+			b, err := AppendBERTLV(nil, f.Interface().([]BERTLV)...)
+			if err != nil {
+				return nil, err
+			}
+			return [][]byte{b}, nil
+		case typ.Kind() == reflect.Struct || isBERTokenizable(typ):
+			s := make([][]byte, 0, f.Len())
+			for i := 0; i < f.Len(); i++ {
+				values, err := tokenizeBER(f.Index(i), tagName)
+				if err != nil {
+					return nil, err
+				}
+				s = append(s, values...)
+			}
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported field type %s passed", f.Type())
+}
+
+// BERTokenizer is the interface providing the Tokenize method for BER-TLV value.
+type BERTokenizer interface {
+	Tokenize() ([]byte, error)
+}
+
+var _berTokenizerInterface = reflect.TypeOf((*BERTokenizer)(nil)).Elem()
+
+func isBERTokenizable(t reflect.Type) bool {
+	return reflect.PtrTo(t).Implements(_berTokenizerInterface)
+}
@@ -0,0 +1,170 @@
+package tlv
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestParseBERTLV(t *testing.T) {
+	long := bytes.Repeat([]byte{0xAB}, 200)
+	tests := []struct {
+		name    string
+		give    []byte
+		want    []BERTLV
+		wantErr bool
+		// wantBytes is the encoding of want if it differs from give.
+		wantBytes []byte
+	}{
+		{
+			name: "pass: single byte tag",
+			give: []byte{0x85, 0x05, 'C', 'P', 'V', '0', '1'},
+			want: []BERTLV{{Tag: "85", Value: []byte("CPV01")}},
+		},
+		{
+			name: "pass: multi byte tag",
+			give: []byte{0x9F, 0x02, 0x06, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x5A, 0x01, 0x12},
+			want: []BERTLV{
+				{Tag: "9F02", Value: []byte{0x00, 0x00, 0x00, 0x00, 0x01, 0x00}},
+				{Tag: "5A", Value: []byte{0x12}},
+			},
+		},
+		{
+			name: "pass: long form length",
+			give: append([]byte{0x5F, 0x50, 0x81, 0xC8}, long...),
+			want: []BERTLV{{Tag: "5F50", Value: long}},
+		},
+		{
+			name:      "pass: padding around data objects",
+			give:      []byte{0x00, 0x85, 0x01, 'C', 0xFF, 0x00, 0x5A, 0x01, 0x12, 0x00, 0x00},
+			want:      []BERTLV{{Tag: "85", Value: []byte("C")}, {Tag: "5A", Value: []byte{0x12}}},
+			wantBytes: []byte{0x85, 0x01, 'C', 0x5A, 0x01, 0x12},
+		},
+		{
+			name:      "pass: padding only",
+			give:      []byte{0x00, 0xFF},
+			want:      nil,
+			wantBytes: []byte{},
+		},
+		{
+			name:    "err: truncated tag",
+			give:    []byte{0x9F},
+			wantErr: true,
+		},
+		{
+			name:    "err: missing length",
+			give:    []byte{0x85},
+			wantErr: true,
+		},
+		{
+			name:    "err: truncated value",
+			give:    []byte{0x85, 0x05, 'C'},
+			wantErr: true,
+		},
+		{
+			name:    "err: long form length exceeds value",
+			give:    []byte{0x85, 0x81, 0x05, 'C'},
+			wantErr: true,
+		},
+		{
+			name:    "err: length of 4 octets exceeds value",
+			give:    []byte{0x85, 0x84, 0xFF, 0xFF, 0xFF, 0xFF, 'C'},
+			wantErr: true,
+		},
+		{
+			name:    "err: indefinite length",
+			give:    []byte{0x61, 0x80, 0x00, 0x00},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBERTLV(tt.give)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseBERTLV() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if _, ok := err.(*MalformedPayloadError); !ok {
+					t.Errorf("ParseBERTLV() unexpected error passed error = %v", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseBERTLV() = %v, want %v", got, tt.want)
+			}
+
+			b, err := AppendBERTLV(nil, got...)
+			if err != nil {
+				t.Fatalf("AppendBERTLV() error = %v", err)
+			}
+			want := tt.give
+			if tt.wantBytes != nil {
+				want = tt.wantBytes
+			}
+			if !bytes.Equal(b, want) {
+				t.Errorf("AppendBERTLV() = %X, want %X", b, want)
+			}
+		})
+	}
+}
+
+func TestAppendBERTLV(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    BERTLV
+		wantErr bool
+	}{
+		{
+			name: "pass",
+			give: BERTLV{Tag: "9F02", Value: []byte{0x01}},
+		},
+		{
+			name:    "err: tag is not hex",
+			give:    BERTLV{Tag: "ZZ"},
+			wantErr: true,
+		},
+		{
+			name:    "err: tag has trailing bytes",
+			give:    BERTLV{Tag: "5A01"},
+			wantErr: true,
+		},
+		{
+			name:    "err: tag is incomplete",
+			give:    BERTLV{Tag: "9F"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.give.Bytes()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BERTLV.Bytes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBERTLV_Constructed(t *testing.T) {
+	tests := []struct {
+		give string
+		want bool
+	}{
+		{give: "61", want: true},
+		{give: "BF0C", want: true},
+		{give: "9F02", want: false},
+		{give: "5A", want: false},
+		{give: "", want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.give, func(t *testing.T) {
+			v := BERTLV{Tag: tt.give}
+			if got := v.Constructed(); got != tt.want {
+				t.Errorf("BERTLV.Constructed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,9 +2,9 @@ package tlv
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"reflect"
-	"strconv"
 )
 
 // MalformedPayloadError indicates given payload is malformed.
@@ -16,6 +16,45 @@ func (e *MalformedPayloadError) Error() string {
 	return e.msg
 }
 
+// Errors represents errors occurred in a single Decode call in the order of occurrence.
+type Errors []error
+
+func (e Errors) Error() string {
+	return errors.Join(e...).Error()
+}
+
+// Unwrap returns the errors to be inspected by errors.Is and errors.As.
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+// ScanError represents an error occurred while scanning the value of a data object into its field.
+type ScanError struct {
+	Tag    string
+	Value  string
+	Offset int // byte offset of the data object in the input
+	Err    error
+}
+
+func (e *ScanError) Error() string {
+	return fmt.Sprintf("failed to scan tag %s: %s", e.Tag, e.Err)
+}
+
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
+
+// DuplicateTagError represents a tag appears more than once.
+type DuplicateTagError struct {
+	Tag             string
+	Offset          int // byte offset of the first data object of the tag
+	DuplicateOffset int // byte offset of the duplicate
+}
+
+func (e *DuplicateTagError) Error() string {
+	return fmt.Sprintf("tag %s at %d duplicates the one at %d", e.Tag, e.DuplicateOffset, e.Offset)
+}
+
 // Decoder reads and decodes TLV payload from an input stream.
 type Decoder struct {
 	r   io.RuneReader
@@ -25,6 +64,11 @@ type Decoder struct {
 	tagLength int
 	lenLength int
 	f         TagLengthTranslator
+	units     lengthUnits
+
+	collectAllErrors    bool
+	rejectDuplicateTags bool
+	repeatableTags      map[string]struct{}
 }
 
 // NewDecoder returns a new decoder that reads from r.
@@ -39,6 +83,38 @@ func NewDecoder(r io.RuneReader, tagName string, bufSize, tagLength, lenLength i
 	}
 }
 
+// CollectAllErrors causes Decode to keep scanning after an error and return every error as Errors.
+func (d *Decoder) CollectAllErrors() {
+	d.collectAllErrors = true
+}
+
+// RejectDuplicateTags causes Decode to return DuplicateTagError when a tag appears more than once,
+// except the repeatable tags. The duplicate is not stored.
+func (d *Decoder) RejectDuplicateTags(repeatable ...string) {
+	d.rejectDuplicateTags = true
+	d.repeatableTags = make(map[string]struct{}, len(repeatable))
+	for _, tag := range repeatable {
+		d.repeatableTags[tag] = struct{}{}
+	}
+}
+
+// LengthUnit sets the unit in which Length counts Value. Without tags, it sets the default unit of the decoder.
+// Tags may be either tags of the payload or the translated ones, and the unit of a tag applies to
+// the data objects nested in it when the field implements UnitScanner.
+// If the default unit is LengthUnitByte, the size of the payload is limited to bufSize bytes.
+func (d *Decoder) LengthUnit(unit LengthUnit, tags ...string) {
+	d.units.set(unit, tags)
+}
+
+// unitOf returns the unit of the data object of tag.
+func (d *Decoder) unitOf(tag, length []rune) LengthUnit {
+	if d.f == nil {
+		return d.units.of(string(tag))
+	}
+	translated, _ := d.f.Translate(tag, length)
+	return d.units.of(string(tag), string(translated))
+}
+
 // Decode reads the next TLV value from its input and stores it in the value pointed to by dst.
 func (d *Decoder) Decode(dst interface{}) error {
 	v := reflect.ValueOf(dst)
@@ -55,82 +131,168 @@ func (d *Decoder) Decode(dst interface{}) error {
 
 	indexes := tagIndexMap(v, d.tagName)
 
-	var n int
+	var n, off int
 	var errs []error
+	seen := make(map[string]int)
 	for {
-		nn, er := readChunk(d.r, d.buf[n:], d.tagLength, d.lenLength)
+		nn, size, er := readChunk(d.r, d.buf[n:], d.tagLength, d.lenLength, d.unitOf)
 		if er != nil {
 			if er != io.EOF {
 				errs = append(errs, er)
 			}
 			break
 		}
+		if d.units.unit == LengthUnitByte && len(d.buf) < off+size {
+			errs = append(errs, &MalformedPayloadError{msg: fmt.Sprintf("payload exceeds %d bytes", len(d.buf))})
+			break
+		}
 
-		if er := scan(v, indexes, d.buf[n:n+nn], d.tagLength, d.lenLength, d.f); er != nil {
+		token := d.buf[n : n+nn]
+		if d.rejectDuplicateTags {
+			tag := string(token[:d.tagLength])
+			if first, ok := seen[tag]; ok {
+				if _, ok := d.repeatableTags[tag]; !ok {
+					errs = append(errs, &DuplicateTagError{Tag: tag, Offset: first, DuplicateOffset: off})
+					n += nn
+					off += size
+					continue
+				}
+			} else {
+				seen[tag] = off
+			}
+		}
+		unit := d.unitOf(token[:d.tagLength], token[d.tagLength:d.tagLength+d.lenLength])
+		if er := scan(v, indexes, token, d.tagLength, d.lenLength, d.f, unit); er != nil {
+			if _, ok := er.(*FieldMissingErr); !ok {
+				er = &ScanError{
+					Tag:    string(d.buf[n : n+d.tagLength]),
+					Value:  string(d.buf[n+d.tagLength+d.lenLength : n+nn]),
+					Offset: off,
+					Err:    er,
+				}
+			}
 			errs = append(errs, er)
 		}
 		n += nn
+		off += size
 	}
-	if len(errs) != 0 {
-		for _, er := range errs {
-			if _, ok := er.(*FieldMissingErr); !ok {
-				return er
-			}
+	var ret Errors
+	for _, er := range errs {
+		if _, ok := er.(*FieldMissingErr); ok {
+			continue
+		}
+		if !d.collectAllErrors {
+			return er
 		}
+		ret = append(ret, er)
+	}
+	if len(ret) != 0 {
+		return ret
 	}
 
 	return nil
 }
 
-func readChunk(r io.RuneReader, b []rune, tagLength, lenLength int) (n int, err error) {
+func readChunk(r io.RuneReader, b []rune, tagLength, lenLength int, unitOf func(tag, length []rune) LengthUnit) (n, size int, err error) {
 	// read Tag
+	chr, s, err := r.ReadRune()
+	if err != nil {
+		return n, size, err // io.EOF at the end of the payload
+	}
 	if len(b) < n+tagLength {
-		return n, &MalformedPayloadError{msg: "cannot read tag"}
+		return n, size, &MalformedPayloadError{msg: "cannot read tag"}
 	}
-	nn, err := readRunes(r, b[:tagLength], tagLength)
+	b[0] = chr
+	nn, ss, err := readRunes(r, b[1:tagLength], tagLength-1)
 	if err != nil {
-		return
+		return n, size, unexpectedEOF(err)
 	}
-	n += nn
+	n += 1 + nn
+	size += s + ss
 
 	// read Length
 	if len(b) < n+lenLength {
-		return n, &MalformedPayloadError{msg: "cannot read value length"}
+		return n, size, &MalformedPayloadError{msg: "cannot read value length"}
 	}
-	nn, err = readRunes(r, b[n:n+lenLength], lenLength)
+	nn, ss, err = readRunes(r, b[n:n+lenLength], lenLength)
 	if err != nil {
-		return
+		return n, size, unexpectedEOF(err)
 	}
-	length, err := strconv.Atoi(string(b[n : n+lenLength]))
-	if err != nil {
-		return n, &MalformedPayloadError{msg: err.Error()}
+	length, ok := parseLength(b[n : n+lenLength])
+	if !ok {
+		return n, size, &MalformedPayloadError{msg: fmt.Sprintf("length %q should be %d digits", string(b[n:n+lenLength]), lenLength)}
 	}
 	n += nn
+	size += ss
 
 	// read Value
-	if len(b) < n+length {
-		return n, &MalformedPayloadError{msg: "cannot read value"}
+	if unitOf(b[:tagLength], b[tagLength:n]) == LengthUnitByte {
+		nn, ss, err = readBytes(r, b[n:], length)
+	} else {
+		if len(b) < n+length {
+			return n, size, &MalformedPayloadError{msg: "cannot read value"}
+		}
+		nn, ss, err = readRunes(r, b[n:n+length], length)
 	}
-	nn, err = readRunes(r, b[n:n+length], length)
 	if err != nil {
-		return
+		return n, size, unexpectedEOF(err)
 	}
 	n += nn
+	size += ss
 
-	if len(b) < n+tagLength {
-		return n, io.EOF
+	return
+}
+
+// parseLength parses Length which consists of ASCII digits only, unlike strconv.Atoi accepting signs.
+func parseLength(b []rune) (int, bool) {
+	var n int
+	for _, r := range b {
+		if r < '0' || '9' < r {
+			return 0, false
+		}
+		n = n*10 + int(r-'0')
 	}
+	return n, true
+}
 
-	return
+// unexpectedEOF converts io.EOF in the middle of a data object into MalformedPayloadError.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return &MalformedPayloadError{msg: "unexpected end of payload"}
+	}
+	return err
 }
 
-func readRunes(r io.RuneReader, b []rune, n int) (int, error) {
+// readRunes reads n runes into b and returns the number of runes and bytes read.
+func readRunes(r io.RuneReader, b []rune, n int) (int, int, error) {
+	var size int
 	for i := 0; i < n; i++ {
-		chr, _, err := r.ReadRune()
+		chr, s, err := r.ReadRune()
+		if err != nil {
+			return 0, 0, err
+		}
+		b[i] = chr
+		size += s
+	}
+	return n, size, nil
+}
+
+// readBytes reads runes of n bytes into b and returns the number of runes and bytes read.
+func readBytes(r io.RuneReader, b []rune, n int) (int, int, error) {
+	var i, size int
+	for ; size < n; i++ {
+		if len(b) <= i {
+			return i, size, &MalformedPayloadError{msg: "cannot read value"}
+		}
+		chr, s, err := r.ReadRune()
 		if err != nil {
-			return 0, err
+			return 0, 0, err
 		}
 		b[i] = chr
+		size += s
+	}
+	if size != n {
+		return i, size, &MalformedPayloadError{msg: "value ends in the middle of a character"}
 	}
-	return n, nil
+	return i, size, nil
 }
@@ -1,6 +1,7 @@
 package tlv
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -39,6 +40,54 @@ func TestTlvDecode(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "truncated tag error",
+			args: args{
+				payload: "003239401ff0c21a4543a8ed5fbaa30ab02e0",
+				bufSize: 64,
+			},
+			wantErr: true,
+		},
+		{
+			name: "truncated length error",
+			args: args{
+				payload: "003239401ff0c21a4543a8ed5fbaa30ab02e010",
+				bufSize: 64,
+			},
+			wantErr: true,
+		},
+		{
+			name: "truncated value error",
+			args: args{
+				payload: "003239401ff0c21a4543a8ed5fbaa30ab02e0105abc",
+				bufSize: 64,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative length error",
+			args: args{
+				payload: "0002ab01-1xxxx",
+				bufSize: 64,
+			},
+			wantErr: true,
+		},
+		{
+			name: "signed length error",
+			args: args{
+				payload: "0002ab01+1xxxx",
+				bufSize: 64,
+			},
+			wantErr: true,
+		},
+		{
+			name: "space in length error",
+			args: args{
+				payload: "0002ab01 1xxxx",
+				bufSize: 64,
+			},
+			wantErr: true,
+		},
 		{
 			name: "pass",
 			args: args{
@@ -61,3 +110,162 @@ func TestTlvDecode(t *testing.T) {
 		})
 	}
 }
+
+func TestDecoder_Decode_lastDataObject(t *testing.T) {
+	payload := "0002ab0103cde"
+	for _, bufSize := range []int{len(payload), len(payload) + 1, len(payload) + 2} {
+		var v struct {
+			A string `emv:"00"`
+			B string `emv:"01"`
+		}
+		if err := NewDecoder(strings.NewReader(payload), "emv", bufSize, 2, 2, nil).Decode(&v); err != nil {
+			t.Fatalf("Decoder.Decode() with bufSize %d error = %v", bufSize, err)
+		}
+		if v.A != "ab" || v.B != "cde" {
+			t.Errorf("Decoder.Decode() with bufSize %d = %+v, want A ab and B cde", bufSize, v)
+		}
+	}
+}
+
+func TestDecoder_CollectAllErrors(t *testing.T) {
+	var v struct {
+		A float64 `emv:"00"`
+		B float64 `emv:"01"`
+		C string  `emv:"02"`
+	}
+	payload := "0001a0102bc0201c"
+
+	if err := NewDecoder(strings.NewReader(payload), "emv", len(payload), 2, 2, nil).Decode(&v); err == nil {
+		t.Fatal("Decoder.Decode() should return error")
+	} else if _, ok := err.(*ScanError); !ok {
+		t.Errorf("Decoder.Decode() error = %v, want *ScanError", err)
+	}
+
+	d := NewDecoder(strings.NewReader(payload), "emv", len(payload), 2, 2, nil)
+	d.CollectAllErrors()
+	err := d.Decode(&v)
+
+	errs, ok := err.(Errors)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("Decoder.Decode() error = %v, want 2 errors", err)
+	}
+	for i, want := range []struct {
+		tag    string
+		offset int
+	}{{"00", 0}, {"01", 5}} {
+		e, ok := errs[i].(*ScanError)
+		if !ok || e.Tag != want.tag || e.Offset != want.offset {
+			t.Errorf("errs[%d] = %v, want tag %s at %d", i, errs[i], want.tag, want.offset)
+		}
+	}
+}
+
+func TestDecoder_LengthUnit(t *testing.T) {
+	type dst struct {
+		Name string `emv:"01"`
+		City string `emv:"02"`
+	}
+	tests := []struct {
+		name    string
+		payload string
+		bufSize int
+		unit    LengthUnit
+		tags    []string
+		want    dst
+		wantErr bool
+	}{
+		{
+			name:    "runes",
+			payload: "0104最佳运输0202北京",
+			bufSize: 512,
+			want:    dst{Name: "最佳运输", City: "北京"},
+		},
+		{
+			name:    "bytes",
+			payload: "0112最佳运输0206北京",
+			bufSize: 512,
+			unit:    LengthUnitByte,
+			want:    dst{Name: "最佳运输", City: "北京"},
+		},
+		{
+			name:    "bytes of tag",
+			payload: "0112最佳运输0202北京",
+			bufSize: 512,
+			unit:    LengthUnitByte,
+			tags:    []string{"01"},
+			want:    dst{Name: "最佳运输", City: "北京"},
+		},
+		{
+			name:    "fail: value ends in the middle of a character",
+			payload: "0111最佳运输0206北京",
+			bufSize: 512,
+			unit:    LengthUnitByte,
+			wantErr: true,
+		},
+		{
+			name:    "fail: payload exceeds bufSize bytes",
+			payload: "0112最佳运输0206北京",
+			bufSize: 20,
+			unit:    LengthUnitByte,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got dst
+			dec := NewDecoder(strings.NewReader(tt.payload), "emv", tt.bufSize, 2, 2, nil)
+			dec.LengthUnit(tt.unit, tt.tags...)
+			err := dec.Decode(&got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Decoder.Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Decoder.Decode() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecoder_OthersTag(t *testing.T) {
+	var v struct {
+		Name   string `emv:"01"`
+		Others []TLV  `emv:"*"`
+	}
+	payload := "0004abcd0104name9902xy"
+	if err := NewDecoder(strings.NewReader(payload), "emv", 512, 2, 2, nil).Decode(&v); err != nil {
+		t.Fatalf("Decoder.Decode() error = %v", err)
+	}
+	want := []TLV{{Tag: "00", Length: "04", Value: "abcd"}, {Tag: "99", Length: "02", Value: "xy"}}
+	if v.Name != "name" || !reflect.DeepEqual(v.Others, want) {
+		t.Errorf("Decoder.Decode() = %+v, want Others %v", v, want)
+	}
+
+	var buf strings.Builder
+	if err := NewEncoder(&buf, "emv", nil, nil).Encode(&v); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	if got, want := buf.String(), "0104name0004abcd9902xy"; got != want {
+		t.Errorf("Encoder.Encode() = %s, want %s", got, want)
+	}
+}
+
+func TestDecoder_RejectDuplicateTags(t *testing.T) {
+	var v struct {
+		Name string `emv:"01"`
+		City string `emv:"02"`
+	}
+	payload := "0104name0204city0104evil0202xy"
+
+	if err := NewDecoder(strings.NewReader(payload), "emv", 512, 2, 2, nil).Decode(&v); err != nil {
+		t.Fatalf("Decoder.Decode() error = %v", err)
+	}
+
+	dec := NewDecoder(strings.NewReader(payload), "emv", 512, 2, 2, nil)
+	dec.RejectDuplicateTags("02")
+	err := dec.Decode(&v)
+	want := &DuplicateTagError{Tag: "01", Offset: 0, DuplicateOffset: 16}
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("Decoder.Decode() error = %v, want %v", err, want)
+	}
+}
@@ -1,2 +1,3 @@
-// Package tlv implements encoding and decoding of TLV (type-length-value or tag-length-value) as defined in EMV Payment Code.
+// Package tlv implements encoding and decoding of TLV (type-length-value or tag-length-value) as defined in EMV Payment Code,
+// and of binary BER-TLV as used by Consumer-Presented Mode and card data.
 package tlv // import "go.mercari.io/go-emv-code/tlv"
@@ -6,10 +6,13 @@ import (
 	"io"
 	"reflect"
 	"strconv"
-	"unicode/utf8"
 )
 
-const tlvEntityFormat = "%s%s%s"
+const (
+	tlvEntityFormat = "%s%s%s"
+
+	maxLength = 99
+)
 
 // Encoder writes EMV Payment Code payload to an output stream.
 type Encoder struct {
@@ -17,6 +20,7 @@ type Encoder struct {
 	tagName    string
 	ignoreTags map[string]struct{}
 	f          TagLengthTranslator
+	units      lengthUnits
 }
 
 // NewEncoder returns a new encoder that writes to w.
@@ -33,6 +37,13 @@ func NewEncoder(w io.Writer, tagName string, ignoreTags []string, f TagLengthTra
 	}
 }
 
+// LengthUnit sets the unit in which Length counts Value. Without tags, it sets the default unit of the encoder.
+// Tags may be either tags of the fields or the ones of TLV, and the unit of a tag applies to
+// the data objects nested in it when the field implements UnitTokenizer.
+func (e *Encoder) LengthUnit(unit LengthUnit, tags ...string) {
+	e.units.set(unit, tags)
+}
+
 // Encode writes TLV payload of src to the stream.
 func (e *Encoder) Encode(src interface{}) error {
 	v := reflect.ValueOf(src)
@@ -53,7 +64,14 @@ func (e *Encoder) Encode(src interface{}) error {
 		index := tag.index
 
 		f := v.Field(index)
-		if isTokenizable(f.Type()) {
+		unit := e.units.of(id)
+		if t, ok := f.Addr().Interface().(UnitTokenizer); ok {
+			nv, err := t.TokenizeUnit(unit)
+			if err != nil {
+				return err
+			}
+			f = reflect.ValueOf(nv)
+		} else if isTokenizable(f.Type()) {
 			var res []reflect.Value
 			if m, ok := reflect.PtrTo(f.Type()).MethodByName("Tokenize"); ok {
 				res = m.Func.Call([]reflect.Value{f.Addr()})
@@ -78,21 +96,31 @@ func (e *Encoder) Encode(src interface{}) error {
 			}
 		}
 
-		v, err := fieldToString(f)
+		v, err := fieldToString(f, func(tag string) LengthUnit { return e.units.of(tag, id) })
 		if err != nil {
-			return fmt.Errorf("failed to convert field value to string: %s", err)
+			return fmt.Errorf("failed to convert field value to string: %w", err)
 		}
 		if len(v) < 1 {
 			continue // value should be non-zero length
 		}
+		if id == OthersTag {
+			if _, err := io.WriteString(e.w, v); err != nil {
+				return fmt.Errorf("failed to write body: %s", err)
+			}
+			continue
+		}
 
-		length := fmt.Sprintf("%02d", utf8.RuneCountInString(v))
+		n := unit.Len(v)
+		length := fmt.Sprintf("%02d", n)
 
 		if e.f != nil {
 			strID, strLength := e.f.Translate([]rune(id), []rune(length))
 			id = string(strID)
 			length = string(strLength)
 		}
+		if length != "" && maxLength < n {
+			return &LengthOverflowError{Tag: id, Length: n, Unit: unit}
+		}
 
 		if _, err := e.w.Write([]byte(fmt.Sprintf(tlvEntityFormat, id, length, v))); err != nil {
 			return fmt.Errorf("failed to write body: %s", err)
@@ -102,7 +130,8 @@ func (e *Encoder) Encode(src interface{}) error {
 	return nil
 }
 
-func fieldToString(v reflect.Value) (ret string, err error) {
+// fieldToString returns v as a string. unitOf returns the unit of TLV of tag.
+func fieldToString(v reflect.Value, unitOf func(tag string) LengthUnit) (ret string, err error) {
 	switch v.Kind() {
 	case reflect.String:
 		ret = v.String()
@@ -113,9 +142,13 @@ func fieldToString(v reflect.Value) (ret string, err error) {
 
 		switch typ {
 		case reflect.TypeOf(TLV{}):
-			for i := 0; i < v.Cap(); i++ {
+			for i := 0; i < v.Len(); i++ {
 				y := v.Index(i).Interface().(TLV)
-				ret = ret + y.token()
+				token, err := y.token(unitOf(y.Tag))
+				if err != nil {
+					return "", err
+				}
+				ret = ret + token
 			}
 		default:
 			return "", fmt.Errorf("unsupported slice element type %s passed", typ.Kind())
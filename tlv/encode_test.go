@@ -0,0 +1,179 @@
+package tlv
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    TLV
+		wantErr bool
+	}{
+		{
+			name:  "runes",
+			value: "最佳运输",
+			want:  TLV{Tag: "59", Length: "04", Value: "最佳运输"},
+		},
+		{
+			name:  "99 runes",
+			value: strings.Repeat("最", 99),
+			want:  TLV{Tag: "59", Length: "99", Value: strings.Repeat("最", 99)},
+		},
+		{
+			name:    "fail: 100 runes",
+			value:   strings.Repeat("a", 100),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New("59", tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				var e *LengthOverflowError
+				if !errors.As(err, &e) || e.Tag != "59" || e.Length != 100 || e.Unit != LengthUnitRune {
+					t.Errorf("New() error = %#v, want LengthOverflowError", err)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("New() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewWithUnit(t *testing.T) {
+	if _, err := NewWithUnit("59", strings.Repeat("最", 34), LengthUnitByte); !errors.As(err, new(*LengthOverflowError)) {
+		t.Errorf("NewWithUnit() error = %#v, want LengthOverflowError", err)
+	}
+}
+
+func TestEncoder_Encode_length(t *testing.T) {
+	isLengthMismatch := func(err error) bool {
+		var e *LengthMismatchError
+		return errors.As(err, &e) && e.Tag == "26" && e.Want == "04"
+	}
+	isLengthOverflow := func(err error) bool {
+		var e *LengthOverflowError
+		return errors.As(err, &e) && e.Length == 100
+	}
+	tests := []struct {
+		name            string
+		tlvs            []TLV
+		value           string
+		want            string
+		wantErr         bool
+		wantErrTypeFunc func(error) bool
+	}{
+		{
+			name: "pass",
+			tlvs: []TLV{{Tag: "26", Length: "04", Value: "abcd"}, {Tag: "27", Length: "02", Value: "ef"}},
+			want: "2604abcd2702ef",
+		},
+		{
+			name: "empty Length is computed",
+			tlvs: []TLV{{Tag: "26", Value: "abcd"}},
+			want: "2604abcd",
+		},
+		{
+			name:            "fail: Length mismatch",
+			tlvs:            []TLV{{Tag: "26", Length: "68", Value: "abcd"}},
+			wantErr:         true,
+			wantErrTypeFunc: isLengthMismatch,
+		},
+		{
+			name:            "fail: Value of TLV is too long",
+			tlvs:            []TLV{{Tag: "26", Value: strings.Repeat("a", 100)}},
+			wantErr:         true,
+			wantErrTypeFunc: isLengthOverflow,
+		},
+		{
+			name:            "fail: Value of field is too long",
+			value:           strings.Repeat("a", 100),
+			wantErr:         true,
+			wantErrTypeFunc: isLengthOverflow,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			v := struct {
+				Value string `emv:"01"`
+				TLVs  []TLV  `emv:"TLVs"`
+			}{Value: tt.value, TLVs: tt.tlvs}
+			var buf strings.Builder
+			translator := TagLengthTranslatorFunc(func(tag, length []rune) ([]rune, []rune) {
+				if string(tag) == "TLVs" {
+					return []rune{}, []rune{}
+				}
+				return tag, length
+			})
+			err := NewEncoder(&buf, "emv", nil, translator).Encode(&v)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Encoder.Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !tt.wantErrTypeFunc(err) {
+					t.Errorf("Encoder.Encode() error = %#v", err)
+				}
+				return
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Encoder.Encode() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncoder_LengthUnit(t *testing.T) {
+	v := struct {
+		Name string `emv:"01"`
+		TLVs []TLV  `emv:"TLVs"`
+	}{
+		Name: "最佳运输",
+		TLVs: []TLV{{Tag: "80", Value: "北京"}, {Tag: "81", Length: "06", Value: "上海"}},
+	}
+	translator := TagLengthTranslatorFunc(func(tag, length []rune) ([]rune, []rune) {
+		if string(tag) == "TLVs" {
+			return []rune{}, []rune{}
+		}
+		return tag, length
+	})
+	tests := []struct {
+		name string
+		tags []string
+		want string
+	}{
+		{
+			name: "bytes",
+			want: "0112最佳运输8006北京8106上海",
+		},
+		{
+			name: "bytes of tag",
+			tags: []string{"01", "81"},
+			want: "0112最佳运输8002北京8106上海",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var buf strings.Builder
+			enc := NewEncoder(&buf, "emv", nil, translator)
+			enc.LengthUnit(LengthUnitByte, tt.tags...)
+			if err := enc.Encode(&v); err != nil {
+				t.Fatalf("Encoder.Encode() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Encoder.Encode() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,10 @@ import (
 	"strconv"
 )
 
+// OthersTag is the tag name of a []TLV field which collects every data object without corresponding field.
+// Encoder writes them as is.
+const OthersTag = "*"
+
 // TLV represents a chunk of TLV payload.
 type TLV struct {
 	Tag    string
@@ -14,8 +18,64 @@ type TLV struct {
 	Value  string
 }
 
-func (t *TLV) token() string {
-	return t.Tag + t.Length + t.Value
+// New returns a TLV of tag and value whose Length is computed from the number of runes of value.
+// It returns LengthOverflowError if value is too long for Length of two digits.
+// Use NewWithUnit for other units.
+func New(tag, value string) (TLV, error) {
+	return NewWithUnit(tag, value, LengthUnitRune)
+}
+
+// NewWithUnit returns a TLV of tag and value whose Length is computed in unit.
+// It returns LengthOverflowError if value is too long for Length of two digits.
+func NewWithUnit(tag, value string, unit LengthUnit) (TLV, error) {
+	l, err := lengthOf(tag, value, unit)
+	if err != nil {
+		return TLV{}, err
+	}
+	return TLV{Tag: tag, Length: l, Value: value}, nil
+}
+
+// token returns t as a string. Empty Length is computed from Value in unit.
+func (t *TLV) token(unit LengthUnit) (string, error) {
+	l, err := lengthOf(t.Tag, t.Value, unit)
+	if err != nil {
+		return "", err
+	}
+	if t.Length != "" && t.Length != l {
+		return "", &LengthMismatchError{Tag: t.Tag, Length: t.Length, Want: l}
+	}
+	return t.Tag + l + t.Value, nil
+}
+
+// lengthOf returns Length of value in unit, or LengthOverflowError if it does not fit in two digits.
+func lengthOf(tag, value string, unit LengthUnit) (string, error) {
+	n := unit.Len(value)
+	if maxLength < n {
+		return "", &LengthOverflowError{Tag: tag, Length: n, Unit: unit}
+	}
+	return fmt.Sprintf("%02d", n), nil
+}
+
+// LengthOverflowError represents Value of tag is too long for Length of two digits.
+type LengthOverflowError struct {
+	Tag    string
+	Length int // length of Value in Unit
+	Unit   LengthUnit
+}
+
+func (e *LengthOverflowError) Error() string {
+	return fmt.Sprintf("length of tag %s exceeds %d %s", e.Tag, maxLength, e.Unit)
+}
+
+// LengthMismatchError represents Length of TLV does not match the length of Value.
+type LengthMismatchError struct {
+	Tag    string
+	Length string
+	Want   string
+}
+
+func (e *LengthMismatchError) Error() string {
+	return fmt.Sprintf("length of tag %s should be %s not %s", e.Tag, e.Want, e.Length)
 }
 
 // FieldMissingErr represents error of field not found for tag.
@@ -27,7 +87,7 @@ func (e *FieldMissingErr) Error() string {
 	return fmt.Sprintf("missing field for tag %s", string(e.Tag))
 }
 
-func scan(v reflect.Value, m map[string]int, token []rune, tagLength, lenLength int, f TagLengthTranslator) error {
+func scan(v reflect.Value, m map[string]int, token []rune, tagLength, lenLength int, f TagLengthTranslator, unit LengthUnit) error {
 	v = reflect.Indirect(v)
 
 	tag := token[:tagLength]
@@ -38,7 +98,11 @@ func scan(v reflect.Value, m map[string]int, token []rune, tagLength, lenLength
 
 	val := token[tagLength+lenLength:]
 
-	if i, ok := m[string(tag)]; ok {
+	i, ok := m[string(tag)]
+	if !ok {
+		i, ok = m[OthersTag]
+	}
+	if ok {
 		f := v.Field(i)
 		if !f.CanSet() {
 			return fmt.Errorf("field must have settability")
@@ -48,6 +112,9 @@ func scan(v reflect.Value, m map[string]int, token []rune, tagLength, lenLength
 			if !f.CanAddr() {
 				return fmt.Errorf("field must have addressability")
 			}
+			if s, ok := f.Addr().Interface().(UnitScanner); ok {
+				return s.ScanUnit(val, unit)
+			}
 
 			var res []reflect.Value
 			if m, ok := reflect.PtrTo(f.Type()).MethodByName("Scan"); ok {
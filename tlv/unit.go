@@ -0,0 +1,71 @@
+package tlv
+
+import "unicode/utf8"
+
+// LengthUnit represents the unit in which Length of a data object counts its Value.
+type LengthUnit int
+
+const (
+	// LengthUnitRune counts Value in characters. It is the default unit.
+	LengthUnitRune LengthUnit = iota
+	// LengthUnitByte counts Value in UTF-8 bytes.
+	LengthUnitByte
+)
+
+// Len returns the length of value in u.
+func (u LengthUnit) Len(value string) int {
+	if u == LengthUnitByte {
+		return len(value)
+	}
+	return utf8.RuneCountInString(value)
+}
+
+func (u LengthUnit) String() string {
+	if u == LengthUnitByte {
+		return "bytes"
+	}
+	return "runes"
+}
+
+// UnitTokenizer is the interface implemented by a Tokenizer of a template
+// to write Lengths of its nested data objects in the unit of the field.
+// Encoder prefers TokenizeUnit to Tokenize.
+type UnitTokenizer interface {
+	TokenizeUnit(unit LengthUnit) (string, error)
+}
+
+// UnitScanner is the interface implemented by a Scanner of a template
+// to read Lengths of its nested data objects in the unit of the field.
+// Decoder prefers ScanUnit to Scan.
+type UnitScanner interface {
+	ScanUnit(token []rune, unit LengthUnit) error
+}
+
+// lengthUnits holds the default unit of a codec and the units of specific tags.
+type lengthUnits struct {
+	unit LengthUnit
+	tags map[string]LengthUnit
+}
+
+func (l *lengthUnits) set(unit LengthUnit, tags []string) {
+	if len(tags) == 0 {
+		l.unit = unit
+		return
+	}
+	if l.tags == nil {
+		l.tags = make(map[string]LengthUnit, len(tags))
+	}
+	for _, tag := range tags {
+		l.tags[tag] = unit
+	}
+}
+
+// of returns the unit of the first tag which has its own unit, or the default unit.
+func (l *lengthUnits) of(tags ...string) LengthUnit {
+	for _, tag := range tags {
+		if u, ok := l.tags[tag]; ok {
+			return u
+		}
+	}
+	return l.unit
+}